@@ -0,0 +1,73 @@
+package jwkutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"fmt"
+
+	jose "github.com/go-jose/go-jose/v4"
+)
+
+// SignatureAlgorithm returns alg, falling back to the alg of jwk and then to
+// the usual algorithm for its key type.
+func SignatureAlgorithm(jwk jose.JSONWebKey, alg string) (jose.SignatureAlgorithm, error) {
+	if alg != "" {
+		return jose.SignatureAlgorithm(alg), nil
+	}
+	if jwk.Algorithm != "" {
+		return jose.SignatureAlgorithm(jwk.Algorithm), nil
+	}
+
+	switch key := jwk.Key.(type) {
+	case *rsa.PrivateKey, *rsa.PublicKey:
+		return jose.RS256, nil
+	case *ecdsa.PrivateKey:
+		return EcdsaAlgorithm(key.Curve.Params().BitSize)
+	case *ecdsa.PublicKey:
+		return EcdsaAlgorithm(key.Curve.Params().BitSize)
+	case ed25519.PrivateKey, ed25519.PublicKey:
+		return jose.EdDSA, nil
+	case []byte:
+		return jose.HS256, nil
+	default:
+		return "", fmt.Errorf("can't infer the algorithm of a %T key", jwk.Key)
+	}
+}
+
+// EcdsaAlgorithm returns the ECDSA signature algorithm of a curve of bitSize
+// bits.
+func EcdsaAlgorithm(bitSize int) (jose.SignatureAlgorithm, error) {
+	switch bitSize {
+	case 256:
+		return jose.ES256, nil
+	case 384:
+		return jose.ES384, nil
+	case 521:
+		return jose.ES512, nil
+	default:
+		return "", fmt.Errorf("unsupported curve size %d", bitSize)
+	}
+}
+
+// KeyAlgorithm returns alg, falling back to the alg of jwk and then to the
+// usual key management algorithm for its key type.
+func KeyAlgorithm(jwk jose.JSONWebKey, alg string) (jose.KeyAlgorithm, error) {
+	if alg != "" {
+		return jose.KeyAlgorithm(alg), nil
+	}
+	if jwk.Algorithm != "" {
+		return jose.KeyAlgorithm(jwk.Algorithm), nil
+	}
+
+	switch jwk.Key.(type) {
+	case *rsa.PrivateKey, *rsa.PublicKey:
+		return jose.RSA_OAEP_256, nil
+	case *ecdsa.PrivateKey, *ecdsa.PublicKey:
+		return jose.ECDH_ES_A256KW, nil
+	case []byte:
+		return jose.A256KW, nil
+	default:
+		return "", fmt.Errorf("can't infer the key management algorithm of a %T key", jwk.Key)
+	}
+}
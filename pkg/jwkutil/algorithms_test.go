@@ -0,0 +1,80 @@
+package jwkutil
+
+import (
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	jose "github.com/go-jose/go-jose/v4"
+)
+
+func TestSignatureAlgorithm(t *testing.T) {
+	rsaKey := mustRsaKey(t)
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		jwk     jose.JSONWebKey
+		alg     string
+		want    jose.SignatureAlgorithm
+		wantErr bool
+	}{
+		{name: "explicit alg", jwk: jose.JSONWebKey{Key: rsaKey, Algorithm: "RS512"}, alg: "PS256", want: jose.PS256},
+		{name: "jwk alg", jwk: jose.JSONWebKey{Key: rsaKey, Algorithm: "RS512"}, want: jose.RS512},
+		{name: "rsa private", jwk: jose.JSONWebKey{Key: rsaKey}, want: jose.RS256},
+		{name: "rsa public", jwk: jose.JSONWebKey{Key: &rsaKey.PublicKey}, want: jose.RS256},
+		{name: "p-256", jwk: jose.JSONWebKey{Key: mustEcdsaKey(t, elliptic.P256())}, want: jose.ES256},
+		{name: "p-384 public", jwk: jose.JSONWebKey{Key: &mustEcdsaKey(t, elliptic.P384()).PublicKey}, want: jose.ES384},
+		{name: "p-521", jwk: jose.JSONWebKey{Key: mustEcdsaKey(t, elliptic.P521())}, want: jose.ES512},
+		{name: "p-224", jwk: jose.JSONWebKey{Key: mustEcdsaKey(t, elliptic.P224())}, wantErr: true},
+		{name: "ed25519 private", jwk: jose.JSONWebKey{Key: edPrivate}, want: jose.EdDSA},
+		{name: "ed25519 public", jwk: jose.JSONWebKey{Key: edPublic}, want: jose.EdDSA},
+		{name: "symmetric", jwk: jose.JSONWebKey{Key: []byte("secret")}, want: jose.HS256},
+		{name: "unknown key", jwk: jose.JSONWebKey{Key: "not a key"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SignatureAlgorithm(tt.jwk, tt.alg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SignatureAlgorithm() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SignatureAlgorithm() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyAlgorithm(t *testing.T) {
+	rsaKey := mustRsaKey(t)
+
+	tests := []struct {
+		name    string
+		jwk     jose.JSONWebKey
+		alg     string
+		want    jose.KeyAlgorithm
+		wantErr bool
+	}{
+		{name: "explicit alg", jwk: jose.JSONWebKey{Key: rsaKey, Algorithm: "RSA-OAEP"}, alg: "RSA1_5", want: jose.RSA1_5},
+		{name: "jwk alg", jwk: jose.JSONWebKey{Key: rsaKey, Algorithm: "RSA-OAEP"}, want: jose.RSA_OAEP},
+		{name: "rsa", jwk: jose.JSONWebKey{Key: &rsaKey.PublicKey}, want: jose.RSA_OAEP_256},
+		{name: "ecdsa", jwk: jose.JSONWebKey{Key: &mustEcdsaKey(t, elliptic.P256()).PublicKey}, want: jose.ECDH_ES_A256KW},
+		{name: "symmetric", jwk: jose.JSONWebKey{Key: []byte("secret")}, want: jose.A256KW},
+		{name: "unknown key", jwk: jose.JSONWebKey{Key: "not a key"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := KeyAlgorithm(tt.jwk, tt.alg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("KeyAlgorithm() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("KeyAlgorithm() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
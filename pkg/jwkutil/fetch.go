@@ -0,0 +1,103 @@
+package jwkutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// JwksDocument is a fetched JWKS. Keys only holds the keys that passed the
+// checks of the caller while Raw is the document as it was served.
+type JwksDocument struct {
+	Header http.Header
+	Keys   []json.RawMessage
+	Raw    []byte
+}
+
+// OidcDiscovery is the subset of an OpenID Provider configuration document
+// used to find the keys of an issuer.
+type OidcDiscovery struct {
+	IdTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+	Issuer                           string   `json:"issuer"`
+	JwksUri                          string   `json:"jwks_uri"`
+}
+
+// GetResponse queries url with the given headers and returns the response
+// body and headers, failing on statuses other than 200.
+func GetResponse(ctx context.Context, client *http.Client, url string, headers map[string]string) ([]byte, http.Header, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	for name, value := range headers {
+		httpReq.Header.Set(name, value)
+	}
+
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer httpResp.Body.Close()
+
+	respData, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("unexpected status %s from %s", httpResp.Status, url)
+	}
+
+	return respData, httpResp.Header, nil
+}
+
+// GetJson queries url with the given headers and decodes the JSON response
+// in v.
+func GetJson(ctx context.Context, client *http.Client, url string, headers map[string]string, v any) error {
+	respData, _, err := GetResponse(ctx, client, url, headers)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(respData, v); err != nil {
+		return fmt.Errorf("can't unmarshal response from %s: %s", url, err)
+	}
+
+	return nil
+}
+
+// FetchJwks queries url once and decodes the JWKS it returns.
+func FetchJwks(ctx context.Context, client *http.Client, url string) (JwksDocument, error) {
+	respData, respHeader, err := GetResponse(ctx, client, url, nil)
+	if err != nil {
+		return JwksDocument{}, err
+	}
+
+	var jwks struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if err := json.Unmarshal(respData, &jwks); err != nil {
+		return JwksDocument{}, fmt.Errorf("can't unmarshal JWKS: %s", err)
+	}
+
+	return JwksDocument{Header: respHeader, Keys: jwks.Keys, Raw: respData}, nil
+}
+
+// FetchOidcDiscovery fetches the OpenID Provider configuration of issuer.
+func FetchOidcDiscovery(ctx context.Context, client *http.Client, issuer string) (OidcDiscovery, error) {
+	var discovery OidcDiscovery
+
+	url := strings.TrimRight(issuer, "/") + "/.well-known/openid-configuration"
+	if err := GetJson(ctx, client, url, nil, &discovery); err != nil {
+		return discovery, err
+	}
+
+	if discovery.JwksUri == "" {
+		return discovery, fmt.Errorf("%s has no jwks_uri", url)
+	}
+
+	return discovery, nil
+}
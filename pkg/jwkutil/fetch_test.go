@@ -0,0 +1,111 @@
+package jwkutil
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+// newJwksServer returns a server answering with the bodies of responses, by
+// path, and a 404 for the other paths.
+func newJwksServer(t *testing.T, responses map[string]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "" {
+			w.Header().Set("X-Authorization", r.Header.Get("Authorization"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchJwks(t *testing.T) {
+	server := newJwksServer(t, map[string]string{
+		"/jwks":    `{"keys":[{"kty":"oct","kid":"a","k":"AA"},{"kty":"oct","kid":"b","k":"AA"}]}`,
+		"/invalid": `{"keys":`,
+	})
+
+	tests := []struct {
+		name    string
+		path    string
+		kids    []string
+		wantErr bool
+	}{
+		{name: "jwks", path: "/jwks", kids: []string{"a", "b"}},
+		{name: "not found", path: "/missing", wantErr: true},
+		{name: "invalid json", path: "/invalid", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := FetchJwks(context.Background(), server.Client(), server.URL+tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FetchJwks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if kids := Kids(doc.Keys); !slices.Equal(kids, tt.kids) {
+				t.Errorf("FetchJwks() kids = %v, want %v", kids, tt.kids)
+			}
+			if doc.Header.Get("Content-Type") != "application/json" || len(doc.Raw) == 0 {
+				t.Errorf("FetchJwks() didn't return the headers and the raw document")
+			}
+		})
+	}
+}
+
+func TestFetchOidcDiscovery(t *testing.T) {
+	server := newJwksServer(t, map[string]string{
+		"/issuer/.well-known/openid-configuration":  `{"issuer":"https://issuer.example.com","jwks_uri":"https://issuer.example.com/keys","id_token_signing_alg_values_supported":["RS256"]}`,
+		"/no-jwks/.well-known/openid-configuration": `{"issuer":"https://issuer.example.com"}`,
+	})
+
+	tests := []struct {
+		name    string
+		issuer  string
+		jwksUri string
+		wantErr bool
+	}{
+		{name: "issuer", issuer: server.URL + "/issuer", jwksUri: "https://issuer.example.com/keys"},
+		{name: "trailing slash", issuer: server.URL + "/issuer/", jwksUri: "https://issuer.example.com/keys"},
+		{name: "no jwks_uri", issuer: server.URL + "/no-jwks", wantErr: true},
+		{name: "not found", issuer: server.URL + "/missing", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discovery, err := FetchOidcDiscovery(context.Background(), server.Client(), tt.issuer)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FetchOidcDiscovery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && discovery.JwksUri != tt.jwksUri {
+				t.Errorf("FetchOidcDiscovery() jwks_uri = %q, want %q", discovery.JwksUri, tt.jwksUri)
+			}
+		})
+	}
+}
+
+func TestGetResponse(t *testing.T) {
+	server := newJwksServer(t, map[string]string{"/": `{}`})
+
+	_, header, err := GetResponse(context.Background(), server.Client(), server.URL+"/", map[string]string{"Authorization": "Bearer token"})
+	if err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+	if got := header.Get("X-Authorization"); got != "Bearer token" {
+		t.Errorf("GetResponse() sent Authorization %q, want %q", got, "Bearer token")
+	}
+
+	var v map[string]string
+	if err := GetJson(context.Background(), server.Client(), server.URL+"/missing", nil, &v); err == nil {
+		t.Errorf("GetJson() didn't fail on a 404")
+	}
+}
@@ -0,0 +1,225 @@
+// Package jwkutil implements the JWK and JWKS conversions of the provider,
+// and the fetching of the JWKS and OIDC discovery documents, so that other
+// tools can share them.
+package jwkutil
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+
+	jose "github.com/go-jose/go-jose/v4"
+)
+
+// ParseJwk parses a single JWK.
+func ParseJwk(data string) (jose.JSONWebKey, error) {
+	var jwk jose.JSONWebKey
+	if err := jwk.UnmarshalJSON([]byte(data)); err != nil {
+		return jwk, err
+	}
+	return jwk, nil
+}
+
+// ParseJwks accepts either a JWKS document or a single JWK and returns the
+// raw keys it contains.
+func ParseJwks(data []byte) ([]json.RawMessage, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	if _, ok := doc["keys"]; ok {
+		var jwks struct {
+			Keys []json.RawMessage `json:"keys"`
+		}
+		if err := json.Unmarshal(data, &jwks); err != nil {
+			return nil, err
+		}
+		return jwks.Keys, nil
+	}
+
+	if _, ok := doc["kty"]; ok {
+		return []json.RawMessage{json.RawMessage(data)}, nil
+	}
+
+	return nil, fmt.Errorf("document is neither a JWK nor a JWKS")
+}
+
+// ParseKeys accepts a JWK, a JWKS or PEM encoded keys and returns the raw
+// keys it contains.
+func ParseKeys(data []byte) ([]json.RawMessage, error) {
+	if IsPem(data) {
+		// pem.Decode only finds blocks starting a line, which IsPem doesn't
+		// require.
+		return PemToJwks(bytes.TrimSpace(data))
+	}
+	return ParseJwks(data)
+}
+
+// PemToJwks converts every PEM block found in data to a JWK.
+func PemToJwks(data []byte) ([]json.RawMessage, error) {
+	var jwks []json.RawMessage
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		jwk, err := PemBlockToJwk(block)
+		if err != nil {
+			return nil, err
+		}
+
+		jwkData, err := jwk.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("can't marshal %s JWK: %s", block.Type, err)
+		}
+		jwks = append(jwks, jwkData)
+	}
+
+	if len(jwks) == 0 {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+
+	return jwks, nil
+}
+
+// PemBlockToJwk converts a public key, private key or certificate PEM block
+// to a JWK.
+func PemBlockToJwk(block *pem.Block) (jose.JSONWebKey, error) {
+	var jwk jose.JSONWebKey
+	var err error
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		jwk.Key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		jwk.Key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PRIVATE KEY":
+		jwk.Key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		jwk.Key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		jwk.Key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "CERTIFICATE":
+		var cert *x509.Certificate
+		cert, err = x509.ParseCertificate(block.Bytes)
+		if err == nil {
+			jwk.Key = cert.PublicKey
+			jwk.Certificates = []*x509.Certificate{cert}
+		}
+	default:
+		return jwk, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+	if err != nil {
+		return jwk, fmt.Errorf("can't parse %s: %s", block.Type, err)
+	}
+
+	return jwk, nil
+}
+
+// X509KeyMapToJwks converts a map of key IDs to PEM certificates to JWKs
+// signing with alg, sorted by key ID.
+func X509KeyMapToJwks(certs map[string]string, alg string) ([]json.RawMessage, error) {
+	var kids []string
+	for kid := range certs {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	var jwks []json.RawMessage
+	for _, kid := range kids {
+		block, _ := pem.Decode([]byte(certs[kid]))
+		if block == nil || block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("no certificate found for kid %s", kid)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse certificate of kid %s: %s", kid, err)
+		}
+
+		jwk := jose.JSONWebKey{
+			Key:          cert.PublicKey,
+			KeyID:        kid,
+			Algorithm:    alg,
+			Use:          "sig",
+			Certificates: []*x509.Certificate{cert},
+		}
+		jwkData, err := jwk.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("can't marshal JWK of kid %s: %s", kid, err)
+		}
+		jwks = append(jwks, jwkData)
+	}
+
+	return jwks, nil
+}
+
+// PublicKey returns the public part of jwk, keeping symmetric keys as is.
+func PublicKey(jwk jose.JSONWebKey) jose.JSONWebKey {
+	if _, ok := jwk.Key.([]byte); ok || jwk.IsPublic() {
+		return jwk
+	}
+	return jwk.Public()
+}
+
+// Thumbprint returns the RFC 7638 thumbprint of jwk computed with hash.
+// go-jose doesn't support symmetric keys, whose required members are k and
+// kty (RFC 7638 section 3.2).
+func Thumbprint(jwk jose.JSONWebKey, hash crypto.Hash) ([]byte, error) {
+	key, ok := jwk.Key.([]byte)
+	if !ok {
+		return jwk.Thumbprint(hash)
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("empty symmetric key")
+	}
+
+	h := hash.New()
+	fmt.Fprintf(h, `{"k":"%s","kty":"oct"}`, base64.RawURLEncoding.EncodeToString(key))
+	return h.Sum(nil), nil
+}
+
+// Kid returns the kid member of a raw JWK.
+func Kid(key json.RawMessage) (string, error) {
+	var header struct {
+		Kid string `json:"kid"`
+	}
+	err := json.Unmarshal(key, &header)
+	return header.Kid, err
+}
+
+// Kids returns the kid member of every raw JWK, empty when it is missing or
+// the key is malformed.
+func Kids(keys []json.RawMessage) []string {
+	kids := make([]string, 0, len(keys))
+	for _, key := range keys {
+		kid, _ := Kid(key)
+		kids = append(kids, kid)
+	}
+	return kids
+}
+
+// K8sKeyId returns the kid the K8S API server derives from its service
+// account signing key, the base64url SHA-256 of the PKIX public key.
+func K8sKeyId(publicKey any) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// IsPem reports whether data looks like PEM encoded content.
+func IsPem(data []byte) bool {
+	return strings.HasPrefix(strings.TrimSpace(string(data)), "-----BEGIN ")
+}
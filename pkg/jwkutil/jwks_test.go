@@ -0,0 +1,419 @@
+package jwkutil
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v4"
+)
+
+// rfc7638Jwk is the example key of RFC 7638 section 3.1, along with its
+// SHA-256 thumbprint.
+const (
+	rfc7638Jwk        = `{"kty":"RSA","n":"0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw","e":"AQAB","alg":"RS256","kid":"2011-04-29"}`
+	rfc7638Thumbprint = "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"
+)
+
+func mustRsaKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func mustEcdsaKey(t *testing.T, curve elliptic.Curve) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// mustCertificate returns a self-signed certificate of key.
+func mustCertificate(t *testing.T, key crypto.Signer) *x509.Certificate {
+	t.Helper()
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "jwkutil"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func pemBlock(blockType string, der []byte) *pem.Block {
+	return &pem.Block{Type: blockType, Bytes: der}
+}
+
+func mustPkix(t *testing.T, key any) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func mustPkcs8(t *testing.T, key any) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func TestParseJwk(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		kid     string
+		wantErr bool
+	}{
+		{name: "rsa", data: rfc7638Jwk, kid: "2011-04-29"},
+		{name: "oct", data: `{"kty":"oct","kid":"hmac","k":"AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8"}`, kid: "hmac"},
+		{name: "unknown kty", data: `{"kty":"XYZ"}`, wantErr: true},
+		{name: "missing members", data: `{"kty":"RSA"}`, wantErr: true},
+		{name: "not json", data: `not json`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwk, err := ParseJwk(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseJwk() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && jwk.KeyID != tt.kid {
+				t.Errorf("ParseJwk() kid = %q, want %q", jwk.KeyID, tt.kid)
+			}
+		})
+	}
+}
+
+func TestParseJwks(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		kids    []string
+		wantErr bool
+	}{
+		{name: "jwks", data: `{"keys":[{"kty":"oct","kid":"a","k":"AA"},{"kty":"oct","kid":"b","k":"AA"}]}`, kids: []string{"a", "b"}},
+		{name: "empty jwks", data: `{"keys":[]}`, kids: []string{}},
+		{name: "single jwk", data: `{"kty":"oct","kid":"a","k":"AA"}`, kids: []string{"a"}},
+		{name: "neither", data: `{"foo":"bar"}`, wantErr: true},
+		{name: "keys not a list", data: `{"keys":{}}`, wantErr: true},
+		{name: "not an object", data: `[]`, wantErr: true},
+		{name: "not json", data: `{`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := ParseJwks([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseJwks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !slices.Equal(Kids(keys), tt.kids) {
+				t.Errorf("ParseJwks() kids = %v, want %v", Kids(keys), tt.kids)
+			}
+		})
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	rsaKey := mustRsaKey(t)
+	pemData := pem.EncodeToMemory(pemBlock("PUBLIC KEY", mustPkix(t, &rsaKey.PublicKey)))
+
+	tests := []struct {
+		name  string
+		data  []byte
+		count int
+	}{
+		{name: "pem", data: append([]byte("\n  "), pemData...), count: 1},
+		{name: "jwks", data: []byte(`{"keys":[` + rfc7638Jwk + `]}`), count: 1},
+		{name: "jwk", data: []byte(rfc7638Jwk), count: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := ParseKeys(tt.data)
+			if err != nil {
+				t.Fatalf("ParseKeys() error = %v", err)
+			}
+			if len(keys) != tt.count {
+				t.Errorf("ParseKeys() returned %d keys, want %d", len(keys), tt.count)
+			}
+		})
+	}
+}
+
+func TestPemToJwks(t *testing.T) {
+	rsaKey := mustRsaKey(t)
+	ecKey := mustEcdsaKey(t, elliptic.P256())
+	publicPem := pem.EncodeToMemory(pemBlock("PUBLIC KEY", mustPkix(t, &rsaKey.PublicKey)))
+	certPem := pem.EncodeToMemory(pemBlock("CERTIFICATE", mustCertificate(t, ecKey).Raw))
+
+	tests := []struct {
+		name    string
+		data    []byte
+		ktys    []string
+		wantErr bool
+	}{
+		{name: "single block", data: publicPem, ktys: []string{"RSA"}},
+		{name: "several blocks", data: append(append([]byte{}, publicPem...), certPem...), ktys: []string{"RSA", "EC"}},
+		{name: "no block", data: []byte("no PEM here"), wantErr: true},
+		{name: "unsupported block", data: pem.EncodeToMemory(pemBlock("DH PARAMETERS", []byte{1})), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := PemToJwks(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PemToJwks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var ktys []string
+			for _, key := range keys {
+				var members struct {
+					Kty string `json:"kty"`
+				}
+				if err := json.Unmarshal(key, &members); err != nil {
+					t.Fatal(err)
+				}
+				ktys = append(ktys, members.Kty)
+			}
+			if !slices.Equal(ktys, tt.ktys) {
+				t.Errorf("PemToJwks() key types = %v, want %v", ktys, tt.ktys)
+			}
+		})
+	}
+}
+
+func TestPemBlockToJwk(t *testing.T) {
+	rsaKey := mustRsaKey(t)
+	ecKey := mustEcdsaKey(t, elliptic.P384())
+	ecDer, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := mustCertificate(t, rsaKey)
+
+	tests := []struct {
+		name         string
+		block        *pem.Block
+		public       bool
+		certificates int
+		wantErr      bool
+	}{
+		{name: "pkix public key", block: pemBlock("PUBLIC KEY", mustPkix(t, &ecKey.PublicKey)), public: true},
+		{name: "pkcs1 public key", block: pemBlock("RSA PUBLIC KEY", x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey)), public: true},
+		{name: "pkcs8 private key", block: pemBlock("PRIVATE KEY", mustPkcs8(t, edKey))},
+		{name: "pkcs1 private key", block: pemBlock("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))},
+		{name: "ec private key", block: pemBlock("EC PRIVATE KEY", ecDer)},
+		{name: "certificate", block: pemBlock("CERTIFICATE", cert.Raw), public: true, certificates: 1},
+		{name: "unsupported type", block: pemBlock("DH PARAMETERS", []byte{1}), wantErr: true},
+		{name: "malformed key", block: pemBlock("PUBLIC KEY", []byte{1, 2, 3}), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwk, err := PemBlockToJwk(tt.block)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PemBlockToJwk() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if jwk.IsPublic() != tt.public {
+				t.Errorf("PemBlockToJwk() public = %v, want %v", jwk.IsPublic(), tt.public)
+			}
+			if len(jwk.Certificates) != tt.certificates {
+				t.Errorf("PemBlockToJwk() returned %d certificates, want %d", len(jwk.Certificates), tt.certificates)
+			}
+			if jwk.Algorithm != "" {
+				t.Errorf("PemBlockToJwk() alg = %q, want none", jwk.Algorithm)
+			}
+		})
+	}
+}
+
+func TestX509KeyMapToJwks(t *testing.T) {
+	certPem := string(pem.EncodeToMemory(pemBlock("CERTIFICATE", mustCertificate(t, mustRsaKey(t)).Raw)))
+	keyPem := string(pem.EncodeToMemory(pemBlock("PUBLIC KEY", mustPkix(t, &mustRsaKey(t).PublicKey))))
+
+	tests := []struct {
+		name    string
+		certs   map[string]string
+		kids    []string
+		wantErr bool
+	}{
+		{name: "sorted by kid", certs: map[string]string{"b": certPem, "a": certPem}, kids: []string{"a", "b"}},
+		{name: "empty", certs: map[string]string{}},
+		{name: "not a certificate", certs: map[string]string{"a": keyPem}, wantErr: true},
+		{name: "not pem", certs: map[string]string{"a": "garbage"}, wantErr: true},
+		{name: "malformed certificate", certs: map[string]string{"a": string(pem.EncodeToMemory(pemBlock("CERTIFICATE", []byte{1})))}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := X509KeyMapToJwks(tt.certs, "RS256")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("X509KeyMapToJwks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if kids := Kids(keys); !slices.Equal(kids, tt.kids) {
+				t.Errorf("X509KeyMapToJwks() kids = %v, want %v", kids, tt.kids)
+			}
+			for _, key := range keys {
+				jwk, err := ParseJwk(string(key))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if jwk.Algorithm != "RS256" || jwk.Use != "sig" || len(jwk.Certificates) != 1 {
+					t.Errorf("X509KeyMapToJwks() alg = %q, use = %q, %d certificates, want RS256, sig and 1", jwk.Algorithm, jwk.Use, len(jwk.Certificates))
+				}
+			}
+		})
+	}
+}
+
+func TestK8sKeyId(t *testing.T) {
+	rsaKey := mustRsaKey(t)
+	ecKey := mustEcdsaKey(t, elliptic.P256())
+	expected := func(key any) string {
+		sum := sha256.Sum256(mustPkix(t, key))
+		return base64.RawURLEncoding.EncodeToString(sum[:])
+	}
+
+	tests := []struct {
+		name    string
+		key     any
+		want    string
+		wantErr bool
+	}{
+		{name: "rsa", key: &rsaKey.PublicKey, want: expected(&rsaKey.PublicKey)},
+		{name: "ecdsa", key: &ecKey.PublicKey, want: expected(&ecKey.PublicKey)},
+		{name: "symmetric", key: []byte("secret"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := K8sKeyId(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("K8sKeyId() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("K8sKeyId() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThumbprint(t *testing.T) {
+	rsa, err := ParseJwk(rfc7638Jwk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		jwk     jose.JSONWebKey
+		want    string
+		wantErr bool
+	}{
+		{name: "rfc 7638 example", jwk: rsa, want: rfc7638Thumbprint},
+		// sha256 of {"k":"AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8","kty":"oct"}
+		{name: "oct", jwk: jose.JSONWebKey{Key: []byte{
+			0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15,
+			16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
+		}}, want: "WqjPPRvAP8oYbAqCwMErhzTg-Quaz-vLx_cef07yhOs"},
+		{name: "empty oct", jwk: jose.JSONWebKey{Key: []byte{}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sum, err := Thumbprint(tt.jwk, crypto.SHA256)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Thumbprint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := base64.RawURLEncoding.EncodeToString(sum); err == nil && got != tt.want {
+				t.Errorf("Thumbprint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublicKey(t *testing.T) {
+	rsaKey := mustRsaKey(t)
+
+	tests := []struct {
+		name string
+		jwk  jose.JSONWebKey
+		want any
+	}{
+		{name: "private", jwk: jose.JSONWebKey{Key: rsaKey}, want: &rsaKey.PublicKey},
+		{name: "public", jwk: jose.JSONWebKey{Key: &rsaKey.PublicKey}, want: &rsaKey.PublicKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PublicKey(tt.jwk).Key.(*rsa.PublicKey)
+			if !got.Equal(tt.want) {
+				t.Errorf("PublicKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	symmetric := PublicKey(jose.JSONWebKey{Key: []byte("secret")})
+	if string(symmetric.Key.([]byte)) != "secret" {
+		t.Errorf("PublicKey() changed a symmetric key")
+	}
+}
+
+func TestKids(t *testing.T) {
+	keys := []json.RawMessage{
+		json.RawMessage(`{"kid":"a"}`),
+		json.RawMessage(`{"kty":"oct"}`),
+		json.RawMessage(`not json`),
+	}
+	if got, want := Kids(keys), []string{"a", "", ""}; !slices.Equal(got, want) {
+		t.Errorf("Kids() = %v, want %v", got, want)
+	}
+}
+
+func TestIsPem(t *testing.T) {
+	tests := []struct {
+		data string
+		want bool
+	}{
+		{data: "-----BEGIN PUBLIC KEY-----\n", want: true},
+		{data: "\n\t -----BEGIN CERTIFICATE-----\n", want: true},
+		{data: `{"kty":"oct"}`},
+		{data: ""},
+	}
+	for _, tt := range tests {
+		if got := IsPem([]byte(tt.data)); got != tt.want {
+			t.Errorf("IsPem(%q) = %v, want %v", tt.data, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"fmt"
+	"slices"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// supportedSignatureAlgorithms are the signature algorithms accepted when
+// parsing a JWS or a JWT, unless restricted by the provider or the data
+// source.
+var supportedSignatureAlgorithms = []jose.SignatureAlgorithm{
+	jose.EdDSA,
+	jose.HS256, jose.HS384, jose.HS512,
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.PS256, jose.PS384, jose.PS512,
+}
+
+// supportedKeyAlgorithms are the key management algorithms accepted when
+// parsing a JWE, unless restricted by the provider or the data source.
+var supportedKeyAlgorithms = []jose.KeyAlgorithm{
+	jose.RSA1_5, jose.RSA_OAEP, jose.RSA_OAEP_256,
+	jose.A128KW, jose.A192KW, jose.A256KW,
+	jose.DIRECT,
+	jose.ECDH_ES, jose.ECDH_ES_A128KW, jose.ECDH_ES_A192KW, jose.ECDH_ES_A256KW,
+	jose.A128GCMKW, jose.A192GCMKW, jose.A256GCMKW,
+	jose.PBES2_HS256_A128KW, jose.PBES2_HS384_A192KW, jose.PBES2_HS512_A256KW,
+}
+
+// supportedContentEncryptions are the content encryption algorithms accepted
+// when parsing a JWE, unless restricted by the provider or the data source.
+var supportedContentEncryptions = []jose.ContentEncryption{
+	jose.A128CBC_HS256, jose.A192CBC_HS384, jose.A256CBC_HS512,
+	jose.A128GCM, jose.A192GCM, jose.A256GCM,
+}
+
+// algorithmList returns the algorithms of list, fallback when it is null.
+// Every algorithm must belong to supported.
+func algorithmList[T ~string](list types.List, supported, fallback []T) ([]T, error) {
+	if list.IsNull() || list.IsUnknown() {
+		return fallback, nil
+	}
+
+	algorithms := []T{}
+	for _, value := range list.Elements() {
+		alg := T(value.(types.String).ValueString())
+		if !slices.Contains(supported, alg) {
+			return nil, fmt.Errorf("unsupported algorithm %q", alg)
+		}
+		algorithms = append(algorithms, alg)
+	}
+	return algorithms, nil
+}
+
+// signatureAlgorithms returns the signature algorithms accepted by a data
+// source, its algorithms when they are set and the provider ones otherwise.
+func (p *JwkProviderData) signatureAlgorithms(algorithms types.List) ([]jose.SignatureAlgorithm, error) {
+	fallback := supportedSignatureAlgorithms
+	if p != nil && p.allowedSignatureAlgorithms != nil {
+		fallback = p.allowedSignatureAlgorithms
+	}
+	algs, err := algorithmList(algorithms, supportedSignatureAlgorithms, fallback)
+	if err != nil {
+		return nil, err
+	}
+	return withoutBanned(p, algs), nil
+}
+
+// encryptionAlgorithms returns the key management and content encryption
+// algorithms accepted by a data source, keyAlgorithms and contentEncryptions
+// when they are set and the provider ones otherwise.
+func (p *JwkProviderData) encryptionAlgorithms(keyAlgorithms, contentEncryptions types.List) ([]jose.KeyAlgorithm, []jose.ContentEncryption, error) {
+	keyFallback, contentFallback := supportedKeyAlgorithms, supportedContentEncryptions
+	if p != nil && p.allowedKeyAlgorithms != nil {
+		keyFallback = p.allowedKeyAlgorithms
+	}
+	if p != nil && p.allowedContentEncryptions != nil {
+		contentFallback = p.allowedContentEncryptions
+	}
+
+	keyAlgs, err := algorithmList(keyAlgorithms, supportedKeyAlgorithms, keyFallback)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid key_encryption_algorithms: %s", err)
+	}
+	contentEncs, err := algorithmList(contentEncryptions, supportedContentEncryptions, contentFallback)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid content_encryption_algorithms: %s", err)
+	}
+	return withoutBanned(p, keyAlgs), withoutBanned(p, contentEncs), nil
+}
+
+// withoutBanned returns algorithms without the banned_algorithms of p.
+func withoutBanned[T ~string](p *JwkProviderData, algorithms []T) []T {
+	if p == nil || len(p.keyPolicy.bannedAlgorithms) == 0 {
+		return algorithms
+	}
+	return slices.DeleteFunc(slices.Clone(algorithms), func(alg T) bool {
+		return slices.Contains(p.keyPolicy.bannedAlgorithms, string(alg))
+	})
+}
+
+// knownAlgorithm reports whether alg is a supported signature, key management
+// or content encryption algorithm.
+func knownAlgorithm(alg string) bool {
+	return slices.Contains(supportedSignatureAlgorithms, jose.SignatureAlgorithm(alg)) ||
+		slices.Contains(supportedKeyAlgorithms, jose.KeyAlgorithm(alg)) ||
+		slices.Contains(supportedContentEncryptions, jose.ContentEncryption(alg))
+}
+
+// rsaMinBits is the RSA key size RFC 7518 requires for the RSA algorithms.
+const rsaMinBits = 2048
+
+// aesKeyBits are the symmetric key sizes of the AES key wrap algorithms.
+var aesKeyBits = map[string]int{
+	string(jose.A128KW): 128, string(jose.A128GCMKW): 128,
+	string(jose.A192KW): 192, string(jose.A192GCMKW): 192,
+	string(jose.A256KW): 256, string(jose.A256GCMKW): 256,
+}
+
+// ecdsaCurves are the curves of the ECDSA signature algorithms.
+var ecdsaCurves = map[string]string{
+	string(jose.ES256): "P-256",
+	string(jose.ES384): "P-384",
+	string(jose.ES512): "P-521",
+}
+
+// checkAlgorithmKey returns an error when alg can't be used with the key of
+// jwk, because of its type, its curve or its size. Unknown algorithms are
+// accepted.
+func checkAlgorithmKey(jwk jose.JSONWebKey, alg string) error {
+	key := publicKey(jwk.Key)
+
+	switch alg {
+	case string(jose.RS256), string(jose.RS384), string(jose.RS512),
+		string(jose.PS256), string(jose.PS384), string(jose.PS512),
+		string(jose.RSA1_5), string(jose.RSA_OAEP), string(jose.RSA_OAEP_256):
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("the %s algorithm requires an RSA key", alg)
+		}
+		if bits := rsaKey.N.BitLen(); bits < rsaMinBits {
+			return fmt.Errorf("the %s algorithm requires RSA keys of at least %d bits, not %d", alg, rsaMinBits, bits)
+		}
+	case string(jose.ES256), string(jose.ES384), string(jose.ES512):
+		ecdsaKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("the %s algorithm requires an EC key", alg)
+		}
+		if name := ecdsaKey.Curve.Params().Name; name != ecdsaCurves[alg] {
+			return fmt.Errorf("the %s algorithm requires the %s curve, not %s", alg, ecdsaCurves[alg], name)
+		}
+	case string(jose.EdDSA):
+		if _, ok := key.(ed25519.PublicKey); !ok {
+			return fmt.Errorf("the %s algorithm requires an Ed25519 key", alg)
+		}
+	case string(jose.ECDH_ES), string(jose.ECDH_ES_A128KW), string(jose.ECDH_ES_A192KW), string(jose.ECDH_ES_A256KW):
+		if _, ok := key.(*ecdsa.PublicKey); !ok {
+			return fmt.Errorf("the %s algorithm requires an EC key", alg)
+		}
+	case string(jose.HS256), string(jose.HS384), string(jose.HS512):
+		symmetricKey, ok := key.([]byte)
+		if !ok {
+			return fmt.Errorf("the %s algorithm requires a symmetric key", alg)
+		}
+		if bits := len(symmetricKey) * 8; bits < hmacMinBits[alg] {
+			return fmt.Errorf("the %s algorithm requires symmetric keys of at least %d bits, not %d", alg, hmacMinBits[alg], bits)
+		}
+	case string(jose.A128KW), string(jose.A192KW), string(jose.A256KW),
+		string(jose.A128GCMKW), string(jose.A192GCMKW), string(jose.A256GCMKW):
+		symmetricKey, ok := key.([]byte)
+		if !ok {
+			return fmt.Errorf("the %s algorithm requires a symmetric key", alg)
+		}
+		if bits := len(symmetricKey) * 8; bits != aesKeyBits[alg] {
+			return fmt.Errorf("the %s algorithm requires symmetric keys of %d bits, not %d", alg, aesKeyBits[alg], bits)
+		}
+	case string(jose.DIRECT), string(jose.PBES2_HS256_A128KW), string(jose.PBES2_HS384_A192KW), string(jose.PBES2_HS512_A256KW):
+		if _, ok := key.([]byte); !ok {
+			return fmt.Errorf("the %s algorithm requires a symmetric key", alg)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,569 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+// AwsCredentialsModel holds the attributes used to authenticate against AWS
+// APIs. It is meant to be embedded in the data source model.
+type AwsCredentialsModel struct {
+	AccessKey    types.String `tfsdk:"access_key"`
+	Profile      types.String `tfsdk:"profile"`
+	Region       types.String `tfsdk:"region"`
+	SecretKey    types.String `tfsdk:"secret_key"`
+	SessionToken types.String `tfsdk:"session_token"`
+}
+
+// imdsTimeout bounds the queries of the EC2 instance metadata service.
+const imdsTimeout = 2 * time.Second
+
+type awsCredentials struct {
+	accessKey    string
+	secretKey    string
+	sessionToken string
+}
+
+// withAwsCredentialsAttributes adds the AwsCredentialsModel attributes to attrs.
+func withAwsCredentialsAttributes(attrs map[string]schema.Attribute) map[string]schema.Attribute {
+	attrs["region"] = schema.StringAttribute{
+		MarkdownDescription: "AWS region, defaults to `AWS_REGION` or `AWS_DEFAULT_REGION`",
+		Optional:            true,
+	}
+	attrs["access_key"] = schema.StringAttribute{
+		MarkdownDescription: "AWS access key, defaults to `AWS_ACCESS_KEY_ID`, the role of `AWS_ROLE_ARN` assumed with the web identity token of `AWS_WEB_IDENTITY_TOKEN_FILE`, the shared credentials file, then the ECS or EKS container credentials or the EC2 instance role",
+		Optional:            true,
+	}
+	attrs["secret_key"] = schema.StringAttribute{
+		MarkdownDescription: "AWS secret key, defaults to `AWS_SECRET_ACCESS_KEY` or the shared credentials file",
+		Optional:            true,
+		Sensitive:           true,
+	}
+	attrs["session_token"] = schema.StringAttribute{
+		MarkdownDescription: "AWS session token, defaults to `AWS_SESSION_TOKEN` or the shared credentials file",
+		Optional:            true,
+		Sensitive:           true,
+	}
+	attrs["profile"] = schema.StringAttribute{
+		MarkdownDescription: "Profile of the shared credentials file, defaults to `AWS_PROFILE` or `default`",
+		Optional:            true,
+	}
+	return attrs
+}
+
+// region returns the configured region, falling back to the environment.
+func (m AwsCredentialsModel) region() (string, error) {
+	for _, region := range []string{m.Region.ValueString(), os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION")} {
+		if region != "" {
+			return region, nil
+		}
+	}
+	return "", fmt.Errorf("no region configured")
+}
+
+// credentials resolves the AWS credentials from the attributes, the
+// environment, the web identity token of AWS_WEB_IDENTITY_TOKEN_FILE, the
+// shared credentials file, the container credentials endpoint and the EC2
+// instance metadata service, in that order. The remote providers are only
+// tried when no profile is set.
+func (m AwsCredentialsModel) credentials(ctx context.Context, client *http.Client) (awsCredentials, error) {
+	if m.AccessKey.ValueString() != "" {
+		return awsCredentials{
+			accessKey:    m.AccessKey.ValueString(),
+			secretKey:    m.SecretKey.ValueString(),
+			sessionToken: m.SessionToken.ValueString(),
+		}, nil
+	}
+
+	if accessKey := os.Getenv("AWS_ACCESS_KEY_ID"); accessKey != "" {
+		return awsCredentials{
+			accessKey:    accessKey,
+			secretKey:    os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			sessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+		}, nil
+	}
+
+	if tokenFile, roleArn := os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE"), os.Getenv("AWS_ROLE_ARN"); tokenFile != "" && roleArn != "" {
+		region, _ := m.region()
+		return webIdentityAwsCredentials(ctx, client, region, tokenFile, roleArn)
+	}
+
+	profile := m.Profile.ValueString()
+	if profile == "" {
+		profile = os.Getenv("AWS_PROFILE")
+	}
+	if profile != "" {
+		return sharedAwsCredentials(profile)
+	}
+	creds, sharedErr := sharedAwsCredentials("default")
+	if sharedErr == nil {
+		return creds, nil
+	}
+
+	if os.Getenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI") != "" || os.Getenv("AWS_CONTAINER_CREDENTIALS_FULL_URI") != "" {
+		return containerAwsCredentials(ctx, client)
+	}
+
+	if !strings.EqualFold(os.Getenv("AWS_EC2_METADATA_DISABLED"), "true") {
+		creds, err := imdsAwsCredentials(ctx, client)
+		if err == nil {
+			return creds, nil
+		}
+		return creds, fmt.Errorf("%s, and no instance credentials: %s", sharedErr, err)
+	}
+	return creds, sharedErr
+}
+
+// sharedAwsCredentials reads profile from the shared credentials file.
+func sharedAwsCredentials(profile string) (awsCredentials, error) {
+	var creds awsCredentials
+
+	path := os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return creds, err
+		}
+		path = filepath.Join(home, ".aws", "credentials")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return creds, fmt.Errorf("no credentials configured: %s", err)
+	}
+	defer file.Close()
+
+	section := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || section != profile {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "aws_access_key_id":
+			creds.accessKey = strings.TrimSpace(value)
+		case "aws_secret_access_key":
+			creds.secretKey = strings.TrimSpace(value)
+		case "aws_session_token":
+			creds.sessionToken = strings.TrimSpace(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return creds, err
+	}
+
+	if creds.accessKey == "" {
+		return creds, fmt.Errorf("profile %q not found in %s", profile, path)
+	}
+	return creds, nil
+}
+
+// awsRemoteCredentials is the response of the container credentials
+// endpoint and of the instance metadata service.
+type awsRemoteCredentials struct {
+	AccessKeyId     string `json:"AccessKeyId"`
+	Code            string `json:"Code"`
+	Message         string `json:"Message"`
+	SecretAccessKey string `json:"SecretAccessKey"`
+	Token           string `json:"Token"`
+}
+
+// containerAwsCredentials fetches the credentials of the ECS task or EKS pod
+// from AWS_CONTAINER_CREDENTIALS_RELATIVE_URI or
+// AWS_CONTAINER_CREDENTIALS_FULL_URI, authenticated with
+// AWS_CONTAINER_AUTHORIZATION_TOKEN_FILE or AWS_CONTAINER_AUTHORIZATION_TOKEN.
+func containerAwsCredentials(ctx context.Context, client *http.Client) (awsCredentials, error) {
+	var creds awsCredentials
+
+	endpoint := os.Getenv("AWS_CONTAINER_CREDENTIALS_FULL_URI")
+	if relativeUri := os.Getenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI"); relativeUri != "" {
+		endpoint = "http://169.254.170.2" + relativeUri
+	} else if err := checkContainerCredentialsUri(endpoint); err != nil {
+		return creds, err
+	}
+
+	token := os.Getenv("AWS_CONTAINER_AUTHORIZATION_TOKEN")
+	if tokenFile := os.Getenv("AWS_CONTAINER_AUTHORIZATION_TOKEN_FILE"); tokenFile != "" {
+		tokenData, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return creds, fmt.Errorf("can't read container authorization token: %s", err)
+		}
+		token = strings.TrimSpace(string(tokenData))
+	}
+	var headers map[string]string
+	if token != "" {
+		headers = map[string]string{"Authorization": token}
+	}
+
+	var remote awsRemoteCredentials
+	if err := jwkutil.GetJson(ctx, client, endpoint, headers, &remote); err != nil {
+		return creds, fmt.Errorf("can't fetch container credentials: %s", err)
+	}
+	return remote.credentials()
+}
+
+// checkContainerCredentialsUri checks that the credentials can't be sent in
+// clear to another host than the local or container credentials endpoints.
+func checkContainerCredentialsUri(endpoint string) error {
+	endpointUrl, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid AWS_CONTAINER_CREDENTIALS_FULL_URI: %s", err)
+	}
+	if endpointUrl.Scheme == "https" {
+		return nil
+	}
+
+	host := endpointUrl.Hostname()
+	ip := net.ParseIP(host)
+	if host == "localhost" || ip != nil && (ip.IsLoopback() || ip.Equal(net.ParseIP("169.254.170.2")) || ip.Equal(net.ParseIP("169.254.170.23")) || ip.Equal(net.ParseIP("fd00:ec2::23"))) {
+		return nil
+	}
+	return fmt.Errorf("AWS_CONTAINER_CREDENTIALS_FULL_URI must use https or a loopback or container credentials host, got %s", endpoint)
+}
+
+// imdsAwsCredentials fetches the credentials of the EC2 instance role from
+// the instance metadata service, at AWS_EC2_METADATA_SERVICE_ENDPOINT when
+// set. Only IMDSv2 is supported.
+func imdsAwsCredentials(ctx context.Context, client *http.Client) (awsCredentials, error) {
+	var creds awsCredentials
+
+	endpoint := strings.TrimRight(os.Getenv("AWS_EC2_METADATA_SERVICE_ENDPOINT"), "/")
+	if endpoint == "" {
+		endpoint = "http://169.254.169.254"
+	}
+
+	// Outside of EC2, the metadata service doesn't answer at all.
+	ctx, cancel := context.WithTimeout(ctx, imdsTimeout)
+	defer cancel()
+
+	tokenReq, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint+"/latest/api/token", nil)
+	if err != nil {
+		return creds, err
+	}
+	tokenReq.Header.Set("X-Aws-Ec2-Metadata-Token-Ttl-Seconds", "21600")
+	tokenResp, err := client.Do(tokenReq)
+	if err != nil {
+		return creds, err
+	}
+	defer tokenResp.Body.Close()
+	token, err := io.ReadAll(tokenResp.Body)
+	if err != nil {
+		return creds, err
+	}
+	if tokenResp.StatusCode != http.StatusOK {
+		return creds, fmt.Errorf("unexpected status %s from %s", tokenResp.Status, tokenReq.URL)
+	}
+	headers := map[string]string{"X-Aws-Ec2-Metadata-Token": string(token)}
+
+	rolesUrl := endpoint + "/latest/meta-data/iam/security-credentials/"
+	roles, _, err := jwkutil.GetResponse(ctx, client, rolesUrl, headers)
+	if err != nil {
+		return creds, err
+	}
+	role, _, _ := strings.Cut(strings.TrimSpace(string(roles)), "\n")
+	if role == "" {
+		return creds, fmt.Errorf("no instance role found at %s", rolesUrl)
+	}
+
+	var remote awsRemoteCredentials
+	if err := jwkutil.GetJson(ctx, client, rolesUrl+url.PathEscape(role), headers, &remote); err != nil {
+		return creds, err
+	}
+	if remote.Code != "" && remote.Code != "Success" {
+		return creds, fmt.Errorf("can't get credentials of instance role %s: %s %s", role, remote.Code, remote.Message)
+	}
+	return remote.credentials()
+}
+
+func (r awsRemoteCredentials) credentials() (awsCredentials, error) {
+	if r.AccessKeyId == "" || r.SecretAccessKey == "" {
+		return awsCredentials{}, fmt.Errorf("credentials response has no access key")
+	}
+	return awsCredentials{
+		accessKey:    r.AccessKeyId,
+		secretKey:    r.SecretAccessKey,
+		sessionToken: r.Token,
+	}, nil
+}
+
+// AwsAssumeRoleWithWebIdentityResp is the response of the STS
+// AssumeRoleWithWebIdentity action.
+type AwsAssumeRoleWithWebIdentityResp struct {
+	Credentials struct {
+		AccessKeyId     string `xml:"AccessKeyId"`
+		SecretAccessKey string `xml:"SecretAccessKey"`
+		SessionToken    string `xml:"SessionToken"`
+	} `xml:"AssumeRoleWithWebIdentityResult>Credentials"`
+}
+
+// webIdentityAwsCredentials exchanges the web identity token of tokenFile,
+// such as the service account token of EKS IRSA, for the credentials of
+// roleArn. STS is reached at AWS_ENDPOINT_URL_STS when set, on its regional
+// endpoint otherwise.
+func webIdentityAwsCredentials(ctx context.Context, client *http.Client, region, tokenFile, roleArn string) (awsCredentials, error) {
+	var creds awsCredentials
+
+	token, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return creds, fmt.Errorf("can't read web identity token: %s", err)
+	}
+
+	endpoint := os.Getenv("AWS_ENDPOINT_URL_STS")
+	if endpoint == "" {
+		endpoint = "https://sts.amazonaws.com/"
+		if region != "" {
+			endpoint = fmt.Sprintf("https://sts.%s.amazonaws.com/", region)
+		}
+	}
+	sessionName := os.Getenv("AWS_ROLE_SESSION_NAME")
+	if sessionName == "" {
+		sessionName = fmt.Sprintf("terraform-provider-jwk-%d", time.Now().UnixNano())
+	}
+
+	form := url.Values{
+		"Action":           {"AssumeRoleWithWebIdentity"},
+		"RoleArn":          {roleArn},
+		"RoleSessionName":  {sessionName},
+		"Version":          {"2011-06-15"},
+		"WebIdentityToken": {strings.TrimSpace(string(token))},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return creds, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	httpResp, err := client.Do(req)
+	if err != nil {
+		return creds, err
+	}
+	defer httpResp.Body.Close()
+	respData, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return creds, err
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return creds, fmt.Errorf("can't assume role %s with web identity: unexpected status %s: %s", roleArn, httpResp.Status, strings.TrimSpace(string(respData)))
+	}
+
+	var stsResp AwsAssumeRoleWithWebIdentityResp
+	if err := xml.Unmarshal(respData, &stsResp); err != nil {
+		return creds, fmt.Errorf("can't unmarshal AssumeRoleWithWebIdentity response: %s", err)
+	}
+	if stsResp.Credentials.AccessKeyId == "" {
+		return creds, fmt.Errorf("AssumeRoleWithWebIdentity response has no credentials")
+	}
+	return awsCredentials{
+		accessKey:    stsResp.Credentials.AccessKeyId,
+		secretKey:    stsResp.Credentials.SecretAccessKey,
+		sessionToken: stsResp.Credentials.SessionToken,
+	}, nil
+}
+
+// awsRequest sends a SigV4 signed request to an AWS API and returns the
+// response body.
+func awsRequest(ctx context.Context, client *http.Client, creds awsCredentials, region, service string, req *http.Request, body []byte) ([]byte, error) {
+	req = req.WithContext(ctx)
+	if body != nil {
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.ContentLength = int64(len(body))
+	}
+	signAwsRequest(req, body, creds, region, service, time.Now().UTC())
+
+	httpResp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	respData, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s: %s", httpResp.Status, strings.TrimSpace(string(respData)))
+	}
+
+	return respData, nil
+}
+
+// awsJsonRequest calls the target action of an AWS JSON 1.1 API, sending in
+// and decoding the response in out.
+func awsJsonRequest(ctx context.Context, client *http.Client, creds awsCredentials, region, service, target string, in, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("https://%s.%s.amazonaws.com/", service, region), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", target)
+
+	respData, err := awsRequest(ctx, client, creds, region, service, req, body)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(respData, out); err != nil {
+		return fmt.Errorf("can't unmarshal %s response: %s", target, err)
+	}
+	return nil
+}
+
+// signAwsRequest adds the AWS Signature Version 4 headers to req, signing
+// all its headers.
+func signAwsRequest(req *http.Request, body []byte, creds awsCredentials, region, service string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Del("Authorization")
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.sessionToken)
+	}
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		// Values are trimmed, their sequential spaces collapsed, and repeated
+		// headers joined in the order they were added.
+		var canonicalValues []string
+		for _, value := range values {
+			canonicalValues = append(canonicalValues, strings.Join(strings.Fields(value), " "))
+		}
+		headers[strings.ToLower(name)] = strings.Join(canonicalValues, ",")
+	}
+
+	var headerNames []string
+	for name := range headers {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range headerNames {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	// Services other than S3 expect the path segments to be encoded twice.
+	canonicalURI := awsUriEscape(req.URL.EscapedPath(), false)
+	if canonicalURI == "" {
+		canonicalURI = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		awsCanonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{date, region, service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := []byte("AWS4" + creds.secretKey)
+	for _, part := range []string{date, region, service, "aws4_request"} {
+		signingKey = hmacSha256(signingKey, part)
+	}
+	signature := hex.EncodeToString(hmacSha256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.accessKey, scope, signedHeaders, signature,
+	))
+}
+
+// awsCanonicalQuery returns the SigV4 canonical query string of query, its
+// parameters sorted by name then value.
+func awsCanonicalQuery(query url.Values) string {
+	escaped := map[string][]string{}
+	var names []string
+	for name, values := range query {
+		name = awsUriEscape(name, true)
+		for _, value := range values {
+			escaped[name] = append(escaped[name], awsUriEscape(value, true))
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var params []string
+	for _, name := range names {
+		values := escaped[name]
+		sort.Strings(values)
+		for _, value := range values {
+			params = append(params, name+"="+value)
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// awsUriEscape percent-encodes s the way SigV4 expects, leaving only the
+// RFC 3986 unreserved characters, and slashes unless escapeSlash is set.
+func awsUriEscape(s string, escapeSlash bool) string {
+	var escaped strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '.', c == '_', c == '~':
+			escaped.WriteByte(c)
+		case c == '/' && !escapeSlash:
+			escaped.WriteByte(c)
+		default:
+			fmt.Fprintf(&escaped, "%%%02X", c)
+		}
+	}
+	return escaped.String()
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TestSignAwsRequest checks signAwsRequest against the AWS Signature Version
+// 4 test suite, signing with its credentials at 20150830T123600Z for the
+// service service of us-east-1.
+func TestSignAwsRequest(t *testing.T) {
+	const stsToken = "AQoDYXdzEPT//////////wEXAMPLEtc764bNrC9SAPBSM22wDOk4x4HIZ8j4FZTwdQWLWsKWHGBuFqwAeMicRXmxfpSPfIeoIYRqTflfKD8YUuwthAx7mSEI/qkPpKPi/kMcGdQrmGdeehM4IC1NtBmUpp2wUE8phUZampKsburEDy0KPkyQDYwT7WZ0wq5VSXDvp75YU9HFvlRd8Tx6q6fE8YQcHNVXAkiY9q6d+xo0rKwT38xVqr7ZD0u0iPPkUL64lIZbqBAz+scqKmlzm8FDrypNC9Yjc8fPOLn9FX9KSYvKTr4rvx3iSIlTJabIQwj2ICCR/oLxBA=="
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		headers       [][2]string
+		body          string
+		sessionToken  string
+		signedHeaders string
+		signature     string
+	}{
+		{name: "get-vanilla", method: "GET", path: "/", signedHeaders: "host;x-amz-date", signature: "5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"},
+		{name: "get-vanilla-empty-query-key", method: "GET", path: "/?Param1=value1", signedHeaders: "host;x-amz-date", signature: "a67d582fa61cc504c4bae71f336f98b97f1ea3c7a6bfe1b6e45aec72011b9aeb"},
+		{name: "get-vanilla-query-order-key-case", method: "GET", path: "/?Param2=value2&Param1=value1", signedHeaders: "host;x-amz-date", signature: "b97d918cfa904a5beff61c982a1b6f458b799221646efd99d3219ec94cdf2500"},
+		{name: "get-vanilla-query-order-value", method: "GET", path: "/?Param1=value2&Param1=Value1", signedHeaders: "host;x-amz-date", signature: "eedbc4e291e521cf13422ffca22be7d2eb8146eecf653089df300a15b2382bd1"},
+		{name: "get-vanilla-query-unreserved", method: "GET", path: "/?-._~0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ_abcdefghijklmnopqrstuvwxyz=-._~0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ_abcdefghijklmnopqrstuvwxyz", signedHeaders: "host;x-amz-date", signature: "c0e2549664ab6caf8a0e49ec520df161cca33ec1de41067db4994a4467d458ff"},
+		{name: "get-vanilla-utf8-query", method: "GET", path: "/?%E1%88%B4=bar", signedHeaders: "host;x-amz-date", signature: "2cdec8eed098649ff3a119c94853b13c643bcf08f8b0a1d91e12c9027818dd04"},
+		{name: "get-unreserved", method: "GET", path: "/-._~0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz", signedHeaders: "host;x-amz-date", signature: "07ef7494c76fa4850883e2b006601f940f8a34d404d0cfa977f52a65bbf5f24f"},
+		{name: "get-utf8", method: "GET", path: "/%E1%88%B4", signedHeaders: "host;x-amz-date", signature: "697b34846207a3f72246f99d74ae1ee4fe54f44bb06730c58a0d339eb079596d"},
+		{name: "get-space", method: "GET", path: "/example%20space/", signedHeaders: "host;x-amz-date", signature: "446b817944c553435b35e813c261ff4e161fff982d1bacdef1c87f6785dd1662"},
+		{name: "get-header-key-duplicate", method: "GET", path: "/", headers: [][2]string{{"My-Header1", "value2"}, {"My-Header1", "value2"}, {"My-Header1", "value1"}}, signedHeaders: "host;my-header1;x-amz-date", signature: "c9d5ea9f3f72853aea855b47ea873832890dbdd183b4468f858259531a5138ea"},
+		{name: "get-header-value-order", method: "GET", path: "/", headers: [][2]string{{"My-Header1", "value4"}, {"My-Header1", "value1"}, {"My-Header1", "value3"}, {"My-Header1", "value2"}}, signedHeaders: "host;my-header1;x-amz-date", signature: "08c7e5a9acfcfeb3ab6b2185e75ce8b1deb5e634ec47601a50643f830c755c01"},
+		{name: "get-header-value-trim", method: "GET", path: "/", headers: [][2]string{{"My-Header1", " value1"}, {"My-Header2", ` "a   b   c"`}}, signedHeaders: "host;my-header1;my-header2;x-amz-date", signature: "acc3ed3afb60bb290fc8d2dd0098b9911fcaa05412b367055dee359757a9c736"},
+		{name: "post-vanilla", method: "POST", path: "/", signedHeaders: "host;x-amz-date", signature: "5da7c1a2acd57cee7505fc6676e4e544621c30862966e37dddb68e92efbe5d6b"},
+		{name: "post-vanilla-query", method: "POST", path: "/?Param1=value1", signedHeaders: "host;x-amz-date", signature: "28038455d6de14eafc1f9222cf5aa6f1a96197d7deb8263271d420d138af7f11"},
+		{name: "post-header-key-sort", method: "POST", path: "/", headers: [][2]string{{"My-Header1", "value1"}}, signedHeaders: "host;my-header1;x-amz-date", signature: "c5410059b04c1ee005303aed430f6e6645f61f4dc9e1461ec8f8916fdf18852c"},
+		{name: "post-header-value-case", method: "POST", path: "/", headers: [][2]string{{"My-Header1", "VALUE1"}}, signedHeaders: "host;my-header1;x-amz-date", signature: "cdbc9802e29d2942e5e10b5bccfdd67c5f22c7c4e8ae67b53629efa58b974b7d"},
+		{name: "post-x-www-form-urlencoded", method: "POST", path: "/", headers: [][2]string{{"Content-Type", "application/x-www-form-urlencoded"}}, body: "Param1=value1", signedHeaders: "content-type;host;x-amz-date", signature: "ff11897932ad3f4e8b18135d722051e5ac45fc38421b1da7b9d196a0fe09473a"},
+		{name: "post-x-www-form-urlencoded-parameters", method: "POST", path: "/", headers: [][2]string{{"Content-Type", "application/x-www-form-urlencoded; charset=utf8"}}, body: "Param1=value1", signedHeaders: "content-type;host;x-amz-date", signature: "1a72ec8f64bd914b0e42e42607c7fbce7fb2c7465f63e3092b3b0d39fa77a6fe"},
+		{name: "post-sts-header-before", method: "POST", path: "/", sessionToken: stsToken, signedHeaders: "host;x-amz-date;x-amz-security-token", signature: "85d96828115b5dc0cfc3bd16ad9e210dd772bbebba041836c64533a82be05ead"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, "https://example.amazonaws.com"+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, header := range tt.headers {
+				req.Header.Add(header[0], header[1])
+			}
+			creds := awsCredentials{
+				accessKey:    "AKIDEXAMPLE",
+				secretKey:    "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+				sessionToken: tt.sessionToken,
+			}
+			signAwsRequest(req, []byte(tt.body), creds, "us-east-1", "service", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+			want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=" + tt.signedHeaders + ", Signature=" + tt.signature
+			if got := req.Header.Get("Authorization"); got != want {
+				t.Errorf("Authorization = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+// newAwsCredentialsServer returns a server playing the container
+// credentials endpoint, the instance metadata service and STS.
+func newAwsCredentialsServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/container":
+			if r.Header.Get("Authorization") != "container-token" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			io.WriteString(w, `{"AccessKeyId":"CONTAINER","SecretAccessKey":"secret","Token":"token","Expiration":"2030-01-01T00:00:00Z"}`)
+		case r.URL.Path == "/latest/api/token":
+			if r.Method != http.MethodPut || r.Header.Get("X-Aws-Ec2-Metadata-Token-Ttl-Seconds") == "" {
+				http.Error(w, "bad token request", http.StatusBadRequest)
+				return
+			}
+			io.WriteString(w, "imds-token")
+		case strings.HasPrefix(r.URL.Path, "/latest/meta-data/iam/security-credentials/"):
+			if r.Header.Get("X-Aws-Ec2-Metadata-Token") != "imds-token" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			if role := strings.TrimPrefix(r.URL.Path, "/latest/meta-data/iam/security-credentials/"); role == "" {
+				io.WriteString(w, "instance-role\n")
+			} else if role == "instance-role" {
+				io.WriteString(w, `{"Code":"Success","AccessKeyId":"INSTANCE","SecretAccessKey":"secret","Token":"token"}`)
+			} else {
+				http.NotFound(w, r)
+			}
+		case r.URL.Path == "/sts":
+			if r.FormValue("Action") != "AssumeRoleWithWebIdentity" || r.FormValue("WebIdentityToken") != "web-identity-token" || r.FormValue("RoleArn") != "arn:aws:iam::123456789012:role/web" {
+				http.Error(w, "<ErrorResponse/>", http.StatusForbidden)
+				return
+			}
+			io.WriteString(w, `<AssumeRoleWithWebIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <AssumeRoleWithWebIdentityResult>
+    <Credentials>
+      <AccessKeyId>WEBIDENTITY</AccessKeyId>
+      <SecretAccessKey>secret</SecretAccessKey>
+      <SessionToken>token</SessionToken>
+    </Credentials>
+  </AssumeRoleWithWebIdentityResult>
+</AssumeRoleWithWebIdentityResponse>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestAwsCredentials(t *testing.T) {
+	server := newAwsCredentialsServer(t)
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	sharedFile := writeFile("credentials", "[default]\naws_access_key_id = SHARED\naws_secret_access_key = secret\n\n[other]\naws_access_key_id = OTHER\naws_secret_access_key = secret\n")
+	tokenFile := writeFile("token", "web-identity-token\n")
+
+	tests := []struct {
+		name      string
+		model     AwsCredentialsModel
+		env       map[string]string
+		accessKey string
+		wantErr   bool
+	}{
+		{
+			name:      "attributes",
+			model:     AwsCredentialsModel{AccessKey: types.StringValue("ATTRIBUTE"), SecretKey: types.StringValue("secret")},
+			env:       map[string]string{"AWS_ACCESS_KEY_ID": "ENV"},
+			accessKey: "ATTRIBUTE",
+		},
+		{
+			name:      "environment",
+			env:       map[string]string{"AWS_ACCESS_KEY_ID": "ENV", "AWS_SECRET_ACCESS_KEY": "secret", "AWS_SHARED_CREDENTIALS_FILE": sharedFile},
+			accessKey: "ENV",
+		},
+		{
+			name:      "web identity",
+			env:       map[string]string{"AWS_WEB_IDENTITY_TOKEN_FILE": tokenFile, "AWS_ROLE_ARN": "arn:aws:iam::123456789012:role/web", "AWS_ENDPOINT_URL_STS": server.URL + "/sts", "AWS_SHARED_CREDENTIALS_FILE": sharedFile},
+			accessKey: "WEBIDENTITY",
+		},
+		{
+			name:    "web identity denied",
+			env:     map[string]string{"AWS_WEB_IDENTITY_TOKEN_FILE": tokenFile, "AWS_ROLE_ARN": "arn:aws:iam::123456789012:role/other", "AWS_ENDPOINT_URL_STS": server.URL + "/sts"},
+			wantErr: true,
+		},
+		{
+			name:      "shared default profile",
+			env:       map[string]string{"AWS_SHARED_CREDENTIALS_FILE": sharedFile, "AWS_EC2_METADATA_SERVICE_ENDPOINT": server.URL},
+			accessKey: "SHARED",
+		},
+		{
+			name:      "shared profile",
+			model:     AwsCredentialsModel{Profile: types.StringValue("other")},
+			env:       map[string]string{"AWS_SHARED_CREDENTIALS_FILE": sharedFile},
+			accessKey: "OTHER",
+		},
+		{
+			name:    "missing profile",
+			env:     map[string]string{"AWS_PROFILE": "missing", "AWS_SHARED_CREDENTIALS_FILE": sharedFile, "AWS_EC2_METADATA_SERVICE_ENDPOINT": server.URL},
+			wantErr: true,
+		},
+		{
+			name:      "container",
+			env:       map[string]string{"AWS_CONTAINER_CREDENTIALS_FULL_URI": server.URL + "/container", "AWS_CONTAINER_AUTHORIZATION_TOKEN": "container-token"},
+			accessKey: "CONTAINER",
+		},
+		{
+			name:    "container without token",
+			env:     map[string]string{"AWS_CONTAINER_CREDENTIALS_FULL_URI": server.URL + "/container"},
+			wantErr: true,
+		},
+		{
+			name:    "container on a remote host",
+			env:     map[string]string{"AWS_CONTAINER_CREDENTIALS_FULL_URI": "http://credentials.example.com/container"},
+			wantErr: true,
+		},
+		{
+			name:      "instance",
+			env:       map[string]string{"AWS_EC2_METADATA_SERVICE_ENDPOINT": server.URL},
+			accessKey: "INSTANCE",
+		},
+		{
+			name:    "instance disabled",
+			env:     map[string]string{"AWS_EC2_METADATA_SERVICE_ENDPOINT": server.URL, "AWS_EC2_METADATA_DISABLED": "true"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{
+				"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN", "AWS_PROFILE",
+				"AWS_WEB_IDENTITY_TOKEN_FILE", "AWS_ROLE_ARN", "AWS_ROLE_SESSION_NAME", "AWS_ENDPOINT_URL_STS",
+				"AWS_CONTAINER_CREDENTIALS_RELATIVE_URI", "AWS_CONTAINER_CREDENTIALS_FULL_URI",
+				"AWS_CONTAINER_AUTHORIZATION_TOKEN", "AWS_CONTAINER_AUTHORIZATION_TOKEN_FILE",
+				"AWS_EC2_METADATA_DISABLED", "AWS_EC2_METADATA_SERVICE_ENDPOINT", "AWS_REGION", "AWS_DEFAULT_REGION",
+			} {
+				t.Setenv(name, tt.env[name])
+			}
+			t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "missing"))
+			if path, ok := tt.env["AWS_SHARED_CREDENTIALS_FILE"]; ok {
+				t.Setenv("AWS_SHARED_CREDENTIALS_FILE", path)
+			}
+
+			creds, err := tt.model.credentials(context.Background(), server.Client())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("credentials() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if creds.accessKey != tt.accessKey {
+				t.Errorf("credentials() access key = %q, want %q", creds.accessKey, tt.accessKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+const (
+	azureKeyVaultApiVersion = "7.4"
+	azureKeyVaultResource   = "https://vault.azure.net"
+	azureManagementResource = "https://management.azure.com"
+	azureMetadataTokenUrl   = "http://169.254.169.254/metadata/identity/oauth2/token"
+)
+
+// AzureCredentialsModel holds the attributes used to authenticate against
+// Azure APIs. It is meant to be embedded in the data source model.
+type AzureCredentialsModel struct {
+	AccessToken  types.String `tfsdk:"access_token"`
+	ClientId     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	TenantId     types.String `tfsdk:"tenant_id"`
+}
+
+type AzureTokenResp struct {
+	AccessToken string `json:"access_token"`
+}
+
+// withAzureCredentialsAttributes adds the AzureCredentialsModel attributes to
+// attrs.
+func withAzureCredentialsAttributes(attrs map[string]schema.Attribute) map[string]schema.Attribute {
+	attrs["access_token"] = schema.StringAttribute{
+		MarkdownDescription: "Azure access token, takes precedence over the service principal and managed identity",
+		Optional:            true,
+		Sensitive:           true,
+	}
+	attrs["tenant_id"] = schema.StringAttribute{
+		MarkdownDescription: "Tenant ID of the service principal, defaults to `ARM_TENANT_ID`",
+		Optional:            true,
+	}
+	attrs["client_id"] = schema.StringAttribute{
+		MarkdownDescription: "Client ID of the service principal or the user assigned managed identity, defaults to `ARM_CLIENT_ID`",
+		Optional:            true,
+	}
+	attrs["client_secret"] = schema.StringAttribute{
+		MarkdownDescription: "Client secret of the service principal, defaults to `ARM_CLIENT_SECRET`. The managed identity is used when unset",
+		Optional:            true,
+		Sensitive:           true,
+	}
+	return attrs
+}
+
+// accessToken resolves an Azure access token for resource.
+func (m AzureCredentialsModel) accessToken(ctx context.Context, client *http.Client, resource string) (string, error) {
+	if token := m.AccessToken.ValueString(); token != "" {
+		return token, nil
+	}
+
+	tenantId := valueOrEnv(m.TenantId, "ARM_TENANT_ID")
+	clientId := valueOrEnv(m.ClientId, "ARM_CLIENT_ID")
+	clientSecret := valueOrEnv(m.ClientSecret, "ARM_CLIENT_SECRET")
+
+	var tokenResp AzureTokenResp
+	if clientSecret != "" {
+		form := url.Values{}
+		form.Set("grant_type", "client_credentials")
+		form.Set("client_id", clientId)
+		form.Set("client_secret", clientSecret)
+		form.Set("scope", resource+"/.default")
+
+		tokenUrl := fmt.Sprintf("https://login.microsoftonline.com/%s/oauth2/v2.0/token", url.PathEscape(tenantId))
+		if err := postForm(ctx, client, tokenUrl, form, &tokenResp); err != nil {
+			return "", err
+		}
+		return tokenResp.AccessToken, nil
+	}
+
+	query := url.Values{}
+	query.Set("api-version", "2018-02-01")
+	query.Set("resource", resource)
+	if clientId != "" {
+		query.Set("client_id", clientId)
+	}
+	err := jwkutil.GetJson(ctx, client, azureMetadataTokenUrl+"?"+query.Encode(), map[string]string{"Metadata": "true"}, &tokenResp)
+	if err != nil {
+		return "", fmt.Errorf("no credentials configured: %s", err)
+	}
+	return tokenResp.AccessToken, nil
+}
+
+// valueOrEnv returns value, falling back to the env environment variable when
+// it is null or empty.
+func valueOrEnv(value types.String, env string) string {
+	if value.ValueString() != "" {
+		return value.ValueString()
+	}
+	return os.Getenv(env)
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	base64urlPassthrough = "passthrough"
+	base64urlNormalize   = "normalize"
+	base64urlReject      = "reject"
+)
+
+var base64urlValues = []string{base64urlPassthrough, base64urlNormalize, base64urlReject}
+
+// jwkBase64urlMembers are the JWK members holding base64url encoded values,
+// x5c being base64 encoded.
+var jwkBase64urlMembers = []string{"d", "dp", "dq", "e", "k", "n", "p", "q", "qi", "x", "x5t", "x5t#S256", "y"}
+
+// canonicalBase64url returns value as canonical base64url: URL alphabet, no
+// padding, no whitespace and zero trailing bits.
+func canonicalBase64url(value string) (string, error) {
+	cleaned := strings.Map(func(r rune) rune {
+		switch r {
+		case '+':
+			return '-'
+		case '/':
+			return '_'
+		case '=', ' ', '\t', '\r', '\n':
+			return -1
+		}
+		return r
+	}, value)
+
+	decoded, err := base64.RawURLEncoding.DecodeString(cleaned)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(decoded), nil
+}
+
+// normalizeBase64url returns key with canonical base64url members along with
+// the names of the members that weren't. key is returned as is when all of
+// them are.
+func normalizeBase64url(key json.RawMessage) (json.RawMessage, []string, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(key, &members); err != nil {
+		return nil, nil, fmt.Errorf("can't unmarshal JWK: %s", err)
+	}
+
+	var changed []string
+	for _, name := range jwkBase64urlMembers {
+		raw, ok := members[name]
+		if !ok {
+			continue
+		}
+
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, nil, fmt.Errorf("can't unmarshal %s: %s", name, err)
+		}
+		canonical, err := canonicalBase64url(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("can't decode %s: %s", name, err)
+		}
+		if canonical != value {
+			changed = append(changed, name)
+			members[name], _ = json.Marshal(canonical)
+		}
+	}
+	if len(changed) == 0 {
+		return key, nil, nil
+	}
+
+	normalized, err := json.Marshal(members)
+	if err != nil {
+		return nil, nil, err
+	}
+	return normalized, changed, nil
+}
+
+// base64urlKeys applies the provider base64url handling to keys: returns
+// them as is, with canonical base64url members, or an error when one of them
+// isn't canonical.
+func (p *JwkProviderData) base64urlKeys(keys []json.RawMessage) ([]json.RawMessage, error) {
+	mode := base64urlPassthrough
+	if p != nil && p.base64url != "" {
+		mode = p.base64url
+	}
+	if mode == base64urlPassthrough {
+		return keys, nil
+	}
+
+	var result []json.RawMessage
+	for i, key := range keys {
+		normalized, changed, err := normalizeBase64url(key)
+		if err != nil {
+			return nil, fmt.Errorf("key %d: %s", i, err)
+		}
+		if len(changed) > 0 && mode == base64urlReject {
+			return nil, fmt.Errorf("key %d has non canonical base64url members: %s", i, strings.Join(changed, ", "))
+		}
+		result = append(result, normalized)
+	}
+	return result, nil
+}
+
+// base64urlJwk calls base64urlKeys for a single JWK.
+func (p *JwkProviderData) base64urlJwk(jwk string) (string, error) {
+	keys, err := p.base64urlKeys([]json.RawMessage{json.RawMessage(jwk)})
+	if err != nil {
+		return "", err
+	}
+	return string(keys[0]), nil
+}
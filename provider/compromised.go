@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"crypto/rsa"
+	"math/big"
+	"slices"
+)
+
+const (
+	// recommendedRsaExponent is the public exponent RSA keys are usually
+	// generated with, smaller ones weakening padding schemes.
+	recommendedRsaExponent = 65537
+
+	// fermatIterations bounds the search for primes close enough to factor
+	// the modulus with Fermat's method.
+	fermatIterations = 1000
+)
+
+// rocaPrimes are the small primes the ROCA fingerprint (CVE-2017-15361)
+// checks the modulus against.
+var rocaPrimes = []int64{
+	3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47, 53, 59, 61, 67, 71, 73,
+	79, 83, 89, 97, 101, 103, 107, 109, 113, 127, 131, 137, 139, 149, 151, 157,
+	163, 167,
+}
+
+// rocaSubgroups are, for every prime of rocaPrimes, the residues of the
+// powers of 65537 modulo the prime.
+var rocaSubgroups = func() map[int64]map[int64]bool {
+	subgroups := map[int64]map[int64]bool{}
+	for _, prime := range rocaPrimes {
+		subgroup := map[int64]bool{}
+		for residue := int64(1); !subgroup[residue]; residue = residue * recommendedRsaExponent % prime {
+			subgroup[residue] = true
+		}
+		subgroups[prime] = subgroup
+	}
+	return subgroups
+}()
+
+// rocaVulnerable reports whether n was generated by the Infineon library
+// affected by ROCA: such moduli are powers of 65537 modulo every prime of
+// rocaPrimes.
+func rocaVulnerable(n *big.Int) bool {
+	residue := new(big.Int)
+	for _, prime := range rocaPrimes {
+		residue.Mod(n, big.NewInt(prime))
+		if !rocaSubgroups[prime][residue.Int64()] {
+			return false
+		}
+	}
+	return true
+}
+
+// smallFactor returns a prime of rocaPrimes, or 2, dividing n, 0 when none
+// does.
+func smallFactor(n *big.Int) int64 {
+	residue := new(big.Int)
+	for _, prime := range append([]int64{2}, rocaPrimes...) {
+		if residue.Mod(n, big.NewInt(prime)).Sign() == 0 {
+			return prime
+		}
+	}
+	return 0
+}
+
+// closePrimes reports whether n is the product of two primes close enough
+// to be found with Fermat's factorization method, a repeated prime included.
+func closePrimes(n *big.Int) bool {
+	a := new(big.Int).Sqrt(n)
+	if new(big.Int).Mul(a, a).Cmp(n) == 0 {
+		return true
+	}
+	a.Add(a, big.NewInt(1))
+
+	b2 := new(big.Int)
+	b := new(big.Int)
+	for range fermatIterations {
+		b2.Mul(a, a)
+		b2.Sub(b2, n)
+		b.Sqrt(b2)
+		if new(big.Int).Mul(b, b).Cmp(b2) == 0 {
+			return true
+		}
+		a.Add(a, big.NewInt(1))
+	}
+	return false
+}
+
+// checkCompromisedRsa adds the known weaknesses of the RSA key key to r.
+func (r *jwkReport) checkCompromisedRsa(key *rsa.PublicKey) {
+	if rocaVulnerable(key.N) {
+		r.errorf("the RSA modulus has the ROCA fingerprint (CVE-2017-15361), the key can be factored")
+	}
+	if prime := smallFactor(key.N); prime != 0 {
+		r.errorf("the RSA modulus is divisible by %d", prime)
+	} else if closePrimes(key.N) {
+		r.errorf("the primes of the RSA modulus are too close, the key can be factored")
+	}
+	if key.E < recommendedRsaExponent {
+		r.warnf("the RSA public exponent %d is small, use %d", key.E, recommendedRsaExponent)
+	}
+}
+
+// checkCompromisedThumbprint adds an error to r when its thumbprint is one of
+// thumbprints, the keys known to be compromised. It is the only check of the
+// keys, like the Debian weak keys, that can't be told from their material
+// alone.
+func (r *jwkReport) checkCompromisedThumbprint(thumbprints []string) {
+	if r.thumbprint != "" && slices.Contains(thumbprints, r.thumbprint) {
+		r.errorf("the key thumbprint %s is listed in compromised_thumbprints", r.thumbprint)
+	}
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+const (
+	conformanceLenient = "lenient"
+	conformanceStrict  = "strict"
+)
+
+var conformanceValues = []string{conformanceLenient, conformanceStrict}
+
+// jwkMembers are the JWK members registered by RFC 7517, RFC 7518 and
+// RFC 8037.
+var jwkMembers = []string{
+	"alg", "crv", "d", "dp", "dq", "e", "k", "key_ops", "kid", "kty", "n", "oth",
+	"p", "q", "qi", "use", "x", "x5c", "x5t", "x5t#S256", "x5u", "y",
+}
+
+// algorithmKeyTypes are the key types the algorithms of RFC 7518 and
+// RFC 8037 can be used with, by algorithm prefix.
+var algorithmKeyTypes = []struct {
+	prefix   string
+	keyTypes []string
+}{
+	{"ECDH-ES", []string{"EC", "OKP"}},
+	{"EdDSA", []string{"OKP"}},
+	{"ES", []string{"EC"}},
+	{"HS", []string{"oct"}},
+	{"PBES2", []string{"oct"}},
+	{"PS", []string{"RSA"}},
+	{"RS", []string{"RSA"}},
+	{"RSA", []string{"RSA"}},
+	{"A", []string{"oct"}},
+	{"dir", []string{"oct"}},
+}
+
+// checkStrictConformance returns an error when key has no kty, lists unknown
+// members in crit, or declares an alg its kty, curve or size can't be used
+// with.
+func checkStrictConformance(key json.RawMessage) error {
+	var members struct {
+		Alg  *string   `json:"alg"`
+		Crit *[]string `json:"crit"`
+		Kty  *string   `json:"kty"`
+	}
+	if err := json.Unmarshal(key, &members); err != nil {
+		return fmt.Errorf("can't unmarshal JWK: %s", err)
+	}
+
+	if members.Kty == nil || *members.Kty == "" {
+		return fmt.Errorf("the kty member is missing")
+	}
+	if members.Crit != nil {
+		for _, name := range *members.Crit {
+			if !slices.Contains(jwkMembers, name) {
+				return fmt.Errorf("unknown critical member %q", name)
+			}
+		}
+	}
+	if members.Alg != nil {
+		for _, algorithm := range algorithmKeyTypes {
+			if !strings.HasPrefix(*members.Alg, algorithm.prefix) {
+				continue
+			}
+			if !slices.Contains(algorithm.keyTypes, *members.Kty) {
+				return fmt.Errorf("the %s algorithm can't be used with %s keys", *members.Alg, *members.Kty)
+			}
+			break
+		}
+	}
+	if jwk, err := jwkutil.ParseJwk(string(key)); err == nil {
+		if err := checkAlgorithmKey(jwk, jwk.Algorithm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkConformance returns an error when the provider conformance is strict
+// and one of keys doesn't conform to it.
+func (p *JwkProviderData) checkConformance(keys []json.RawMessage) error {
+	if p == nil || p.conformance != conformanceStrict {
+		return nil
+	}
+	for i, key := range keys {
+		if err := checkStrictConformance(key); err != nil {
+			return fmt.Errorf("key %d: %s", i, err)
+		}
+	}
+	return nil
+}
+
+// checkConformanceJwk is checkConformance for a single JWK.
+func (p *JwkProviderData) checkConformanceJwk(jwk string) error {
+	if p == nil || p.conformance != conformanceStrict {
+		return nil
+	}
+	return checkStrictConformance(json.RawMessage(jwk))
+}
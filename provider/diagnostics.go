@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+// jwkHint returns how to fix data when it is obviously not a JWK, or a JWKS
+// when jwks is set, empty otherwise.
+func jwkHint(data string, jwks bool) string {
+	data = strings.TrimSpace(data)
+
+	var doc map[string]json.RawMessage
+	isJson := json.Unmarshal([]byte(data), &doc) == nil
+	_, hasKeys := doc["keys"]
+
+	switch {
+	case data == "":
+		return "the value is empty"
+	case jwkutil.IsPem([]byte(data)):
+		return "the value looks like PEM, read it with the jwk_from_file data source to convert it to JWKs"
+	case strings.HasPrefix(data, "eyJ") && strings.Count(data, ".") >= 2:
+		return "the value looks like a JWT or a JWS, not a JWK"
+	case isJson && hasKeys && !jwks:
+		return "the value looks like a JWKS, select one of its keys with jsonencode(jsondecode(...).keys[0])"
+	case !isJson && looksLikeBase64Json(data):
+		return "the value looks base64 encoded, decode it with base64decode()"
+	}
+	return ""
+}
+
+// looksLikeBase64Json reports whether data is the base64 encoding of a JSON
+// object.
+func looksLikeBase64Json(data string) bool {
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if decoded, err := encoding.DecodeString(data); err == nil {
+			return strings.HasPrefix(strings.TrimSpace(string(decoded)), "{")
+		}
+	}
+	return false
+}
+
+// withJwkHint appends the hint of data, see jwkHint, to detail.
+func withJwkHint(detail, data string, jwks bool) string {
+	if hint := jwkHint(data, jwks); hint != "" {
+		return detail + "\n\nHint: " + hint
+	}
+	return detail
+}
+
+// keySetPath returns the path of the attribute read by keySet, jwk when it is
+// set and jwks otherwise.
+func keySetPath(jwk types.String) path.Path {
+	if jwk.IsNull() {
+		return path.Root("jwks")
+	}
+	return path.Root("jwk")
+}
+
+// keySetIndexPath returns the path of the key at index i of the keys read by
+// keySet.
+func keySetIndexPath(jwk types.String, i int) path.Path {
+	if jwk.IsNull() {
+		return path.Root("jwks").AtListIndex(i)
+	}
+	return path.Root("jwk")
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+// defaultExpiryWarningDays is the number of days before their expiry keys
+// are reported, unless set by the provider expiry_warning_days.
+const defaultExpiryWarningDays = 30
+
+// keyExpiry returns when key expires: its exp member or the end of validity
+// of the leaf certificate of its x5c chain, whichever comes first. ok is
+// false for keys without expiry.
+func keyExpiry(key json.RawMessage) (expiry time.Time, ok bool) {
+	var members struct {
+		Exp *float64 `json:"exp"`
+	}
+	if err := json.Unmarshal(key, &members); err == nil && members.Exp != nil {
+		expiry, ok = time.Unix(int64(*members.Exp), 0), true
+	}
+
+	if jwk, err := jwkutil.ParseJwk(string(key)); err == nil && len(jwk.Certificates) > 0 {
+		if notAfter := jwk.Certificates[0].NotAfter; !ok || notAfter.Before(expiry) {
+			expiry, ok = notAfter, true
+		}
+	}
+	return expiry, ok
+}
+
+// expiryWarning returns how long before their expiry keys are reported, 0
+// when they aren't.
+func (p *JwkProviderData) expiryWarning() time.Duration {
+	if p == nil {
+		return defaultExpiryWarningDays * 24 * time.Hour
+	}
+	return p.expiryWarningWindow
+}
+
+// checkExpiringKeys adds a warning for every key read by keySet expiring
+// within the provider expiry_warning_days, jwk being null when they come from
+// the jwks attribute.
+func (p *JwkProviderData) checkExpiringKeys(diags *diag.Diagnostics, jwk types.String, keys []json.RawMessage) {
+	window := p.expiryWarning()
+	if window <= 0 {
+		return
+	}
+
+	now := time.Now()
+	for i, key := range keys {
+		expiry, ok := keyExpiry(key)
+		if !ok || expiry.After(now.Add(window)) {
+			continue
+		}
+
+		name := fmt.Sprintf("Key %d", i)
+		if kid, err := jwkutil.Kid(key); err == nil && kid != "" {
+			name = fmt.Sprintf("Key %q", kid)
+		}
+		if expiry.Before(now) {
+			diags.AddAttributeWarning(keySetIndexPath(jwk, i), "Expired key", fmt.Sprintf("%s expired on %s", name, expiry.UTC().Format(time.RFC3339)))
+		} else {
+			diags.AddAttributeWarning(keySetIndexPath(jwk, i), "Expiring key", fmt.Sprintf("%s expires on %s, rotate it", name, expiry.UTC().Format(time.RFC3339)))
+		}
+	}
+}
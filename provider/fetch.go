@@ -0,0 +1,536 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+const (
+	defaultPollInterval = 5 * time.Second
+	defaultWaitTimeout  = 5 * time.Minute
+	defaultMaxRedirects = 10
+)
+
+// FetchOptionsModel holds the attributes shared by every data source that
+// fetches JWKs from a remote endpoint. It is meant to be embedded in the data
+// source model.
+type FetchOptionsModel struct {
+	ConnectAddress    types.String `tfsdk:"connect_address"`
+	DropInvalidKeys   types.Bool   `tfsdk:"drop_invalid_keys"`
+	ExpectedSha256    types.String `tfsdk:"expected_sha256"`
+	IpVersion         types.String `tfsdk:"ip_version"`
+	LocalAddress      types.String `tfsdk:"local_address"`
+	MaxRedirects      types.Int64  `tfsdk:"max_redirects"`
+	MinKeys           types.Int64  `tfsdk:"min_keys"`
+	PollInterval      types.String `tfsdk:"poll_interval"`
+	RetryAttempts     types.Int64  `tfsdk:"retry_attempts"`
+	RetryMaxBackoff   types.String `tfsdk:"retry_max_backoff"`
+	RetryMinBackoff   types.String `tfsdk:"retry_min_backoff"`
+	SameHostRedirects types.Bool   `tfsdk:"same_host_redirects"`
+	ServerName        types.String `tfsdk:"server_name"`
+	SshHost           types.String `tfsdk:"ssh_host"`
+	SshHostKey        types.String `tfsdk:"ssh_host_key"`
+	SshPrivateKey     types.String `tfsdk:"ssh_private_key"`
+	SshUser           types.String `tfsdk:"ssh_user"`
+	Strict            types.Bool   `tfsdk:"strict"`
+	Timeout           types.String `tfsdk:"timeout"`
+	UnixSocket        types.String `tfsdk:"unix_socket"`
+	WaitForKid        types.String `tfsdk:"wait_for_kid"`
+	WaitTimeout       types.String `tfsdk:"wait_timeout"`
+}
+
+// withFetchOptionsAttributes adds the FetchOptionsModel attributes to attrs.
+func withFetchOptionsAttributes(attrs map[string]schema.Attribute) map[string]schema.Attribute {
+	attrs["wait_for_kid"] = schema.StringAttribute{
+		MarkdownDescription: "Poll the endpoint until a key with this kid is published",
+		Optional:            true,
+	}
+	attrs["poll_interval"] = schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("Interval between two polls when `wait_for_kid` is set (default: `%s`)", defaultPollInterval),
+		Optional:            true,
+	}
+	attrs["wait_timeout"] = schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("Maximum time to wait for `wait_for_kid` to be published (default: `%s`)", defaultWaitTimeout),
+		Optional:            true,
+	}
+	attrs["min_keys"] = schema.Int64Attribute{
+		MarkdownDescription: "Fail if the endpoint publishes fewer keys than this",
+		Optional:            true,
+	}
+	attrs["strict"] = schema.BoolAttribute{
+		MarkdownDescription: "Parse every fetched key and fail if one of them is malformed",
+		Optional:            true,
+	}
+	attrs["drop_invalid_keys"] = schema.BoolAttribute{
+		MarkdownDescription: "When `strict` is set, drop malformed keys with a warning instead of failing",
+		Optional:            true,
+	}
+	attrs["expected_sha256"] = schema.StringAttribute{
+		MarkdownDescription: "Fail if the hex encoded SHA-256 digest of the fetched document doesn't match this",
+		Optional:            true,
+	}
+	attrs["connect_address"] = schema.StringAttribute{
+		MarkdownDescription: "Address (`host[:port]`) to connect to instead of the host of the fetched URLs, the port of the URL is kept when omitted",
+		Optional:            true,
+	}
+	attrs["server_name"] = schema.StringAttribute{
+		MarkdownDescription: "Server name sent for SNI and used to verify the certificate of the endpoint, defaults to the host of the fetched URL",
+		Optional:            true,
+	}
+	attrs["unix_socket"] = schema.StringAttribute{
+		MarkdownDescription: "Path of a unix socket to connect to instead of the host of the fetched URLs",
+		Optional:            true,
+	}
+	attrs["ssh_host"] = schema.StringAttribute{
+		MarkdownDescription: "Bastion (`host[:port]`) to tunnel the connections through over SSH",
+		Optional:            true,
+	}
+	attrs["ssh_user"] = schema.StringAttribute{
+		MarkdownDescription: "User to connect to `ssh_host` as, defaults to `USER`",
+		Optional:            true,
+	}
+	attrs["ssh_private_key"] = schema.StringAttribute{
+		MarkdownDescription: "Private key authenticating to `ssh_host`, defaults to the keys of the SSH agent",
+		Optional:            true,
+		Sensitive:           true,
+	}
+	attrs["ssh_host_key"] = schema.StringAttribute{
+		MarkdownDescription: "Public key of `ssh_host` in authorized_keys format, defaults to the keys listed in `~/.ssh/known_hosts`",
+		Optional:            true,
+	}
+	attrs["max_redirects"] = schema.Int64Attribute{
+		MarkdownDescription: fmt.Sprintf("Maximum number of redirects to follow, `0` to disable them (default: `%d`)", defaultMaxRedirects),
+		Optional:            true,
+	}
+	attrs["same_host_redirects"] = schema.BoolAttribute{
+		MarkdownDescription: "Fail when a redirect leads to a different host than the one of the fetched URL",
+		Optional:            true,
+	}
+	attrs["ip_version"] = schema.StringAttribute{
+		MarkdownDescription: "IP version to connect over, `4` or `6`, both are tried by default",
+		Optional:            true,
+	}
+	attrs["local_address"] = schema.StringAttribute{
+		MarkdownDescription: "Local IP address to connect from",
+		Optional:            true,
+	}
+	attrs["timeout"] = schema.StringAttribute{
+		MarkdownDescription: "Timeout of each request, defaults to the `timeout` of the provider",
+		Optional:            true,
+	}
+	attrs["retry_attempts"] = schema.Int64Attribute{
+		MarkdownDescription: "Number of times a failed request is retried, defaults to the `retry_attempts` of the provider",
+		Optional:            true,
+	}
+	attrs["retry_min_backoff"] = schema.StringAttribute{
+		MarkdownDescription: "Delay before the first retry, doubled on every retry, defaults to the `retry_min_backoff` of the provider",
+		Optional:            true,
+	}
+	attrs["retry_max_backoff"] = schema.StringAttribute{
+		MarkdownDescription: "Maximum delay between two retries, defaults to the `retry_max_backoff` of the provider",
+		Optional:            true,
+	}
+	return attrs
+}
+
+// fetchClient returns a copy of client applying the connection options to
+// every request it sends.
+func (m FetchOptionsModel) fetchClient(client *http.Client) (*http.Client, error) {
+	transport, err := m.transport(client.Transport)
+	if err != nil {
+		return nil, err
+	}
+
+	fetchClient := *client
+	fetchClient.Transport = transport
+	if fetchClient.Timeout, err = parseDuration(m.Timeout, client.Timeout); err != nil {
+		return nil, fmt.Errorf("invalid timeout: %s", err)
+	}
+	if !m.MaxRedirects.IsNull() || m.SameHostRedirects.ValueBool() {
+		fetchClient.CheckRedirect = m.checkRedirect
+	}
+	return &fetchClient, nil
+}
+
+// checkRedirect enforces the redirect options before following req.
+func (m FetchOptionsModel) checkRedirect(req *http.Request, via []*http.Request) error {
+	maxRedirects := int64(defaultMaxRedirects)
+	if !m.MaxRedirects.IsNull() {
+		maxRedirects = m.MaxRedirects.ValueInt64()
+	}
+	if int64(len(via)) > maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+
+	if m.SameHostRedirects.ValueBool() && req.URL.Host != via[0].URL.Host {
+		return fmt.Errorf("redirect from %s to a different host %s", via[0].URL.Host, req.URL.Host)
+	}
+	return nil
+}
+
+// transport returns a copy of rt applying the connection options.
+func (m FetchOptionsModel) transport(rt http.RoundTripper) (http.RoundTripper, error) {
+	switch t := rt.(type) {
+	case nil:
+		return m.transport(http.DefaultTransport)
+	case *headerTransport:
+		base, err := m.transport(t.base)
+		if err != nil {
+			return nil, err
+		}
+		return &headerTransport{base: base, headers: t.headers, host: t.host}, nil
+	case offlineTransport, *mockTransport:
+		return t, nil
+	case *limitTransport:
+		base, err := m.transport(t.base)
+		if err != nil {
+			return nil, err
+		}
+		return &limitTransport{base: base, slots: t.slots}, nil
+	case *logTransport:
+		base, err := m.transport(t.base)
+		if err != nil {
+			return nil, err
+		}
+		return &logTransport{base: base, trace: t.trace}, nil
+	case *retryTransport:
+		base, err := m.transport(t.base)
+		if err != nil {
+			return nil, err
+		}
+		policy, err := t.policy.withRetryOverrides(m.RetryAttempts, m.RetryMinBackoff, m.RetryMaxBackoff)
+		if err != nil {
+			return nil, err
+		}
+		return &retryTransport{base: base, policy: policy}, nil
+	case *http.Transport:
+		if m.ConnectAddress.ValueString() != "" && m.UnixSocket.ValueString() != "" {
+			return nil, fmt.Errorf("connect_address and unix_socket are mutually exclusive")
+		}
+
+		transport := t.Clone()
+		if serverName := m.ServerName.ValueString(); serverName != "" {
+			if transport.TLSClientConfig == nil {
+				transport.TLSClientConfig = &tls.Config{}
+			}
+			transport.TLSClientConfig.ServerName = serverName
+		}
+		dialer := &net.Dialer{}
+		if localAddress := m.LocalAddress.ValueString(); localAddress != "" {
+			ip := net.ParseIP(localAddress)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid local_address %q", localAddress)
+			}
+			dialer.LocalAddr = &net.TCPAddr{IP: ip}
+		}
+
+		var tcpNetwork string
+		switch ipVersion := m.IpVersion.ValueString(); ipVersion {
+		case "":
+			tcpNetwork = "tcp"
+		case "4", "6":
+			tcpNetwork = "tcp" + ipVersion
+		default:
+			return nil, fmt.Errorf("unsupported ip_version %q", ipVersion)
+		}
+
+		dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+			if network == "tcp" {
+				network = tcpNetwork
+			}
+			return dialer.DialContext(ctx, network, addr)
+		}
+		if m.SshHost.ValueString() != "" {
+			tunnel, err := m.sshTunnel(dial)
+			if err != nil {
+				return nil, err
+			}
+			dial = tunnel.DialContext
+		}
+
+		connectAddress, unixSocket := m.ConnectAddress.ValueString(), m.UnixSocket.ValueString()
+		if connectAddress != "" || unixSocket != "" || m.SshHost.ValueString() != "" || dialer.LocalAddr != nil || tcpNetwork != "tcp" {
+			transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+				if unixSocket != "" {
+					network, addr = "unix", unixSocket
+				} else if connectAddress != "" {
+					addr = withDefaultPort(connectAddress, addr)
+				}
+				if network == "tcp" {
+					network = tcpNetwork
+				}
+				return dial(ctx, network, addr)
+			}
+		}
+		return transport, nil
+	default:
+		return nil, fmt.Errorf("unsupported transport %T", rt)
+	}
+}
+
+// withDefaultPort returns address with the port of addr when it has none.
+func withDefaultPort(address, addr string) string {
+	if _, _, err := net.SplitHostPort(address); err == nil {
+		return address
+	}
+	_, port, _ := net.SplitHostPort(addr)
+	return net.JoinHostPort(strings.Trim(address, "[]"), port)
+}
+
+// headerTransport sets headers on the requests sent through base, only on
+// the requests to host when it is set.
+type headerTransport struct {
+	base    http.RoundTripper
+	headers map[string]string
+	host    string
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.host != "" && req.URL.Host != t.host {
+		return t.base.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	for name, value := range t.headers {
+		req.Header.Set(name, value)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// withHeaders returns a copy of client sending headers on the requests to the
+// host of address, on every request when address is empty. Credentials
+// scoped this way don't follow redirects or links to other hosts.
+func withHeaders(client *http.Client, address string, headers map[string]string) *http.Client {
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	host := address
+	if addressUrl, err := url.Parse(address); err == nil && addressUrl.Host != "" {
+		host = addressUrl.Host
+	}
+
+	headersClient := *client
+	headersClient.Transport = &headerTransport{base: base, headers: headers, host: host}
+	return &headersClient
+}
+
+// parseDuration parses value as a duration, returning fallback when value is
+// null or empty.
+func parseDuration(value types.String, fallback time.Duration) (time.Duration, error) {
+	if value.ValueString() == "" {
+		return fallback, nil
+	}
+	return time.ParseDuration(value.ValueString())
+}
+
+// fetchJwks fetches the JWKS published at url, honoring the fetch options.
+func fetchJwks(ctx context.Context, client *http.Client, url string, opts FetchOptionsModel) ([]json.RawMessage, diag.Diagnostics) {
+	doc, diags := fetchJwksDocument(ctx, client, url, opts)
+	return doc.Keys, diags
+}
+
+// jwksGetter fetches a JWKS document once.
+type jwksGetter func(ctx context.Context) (jwkutil.JwksDocument, error)
+
+// fetchJwksDocument is like fetchJwks but also returns the raw document.
+func fetchJwksDocument(ctx context.Context, client *http.Client, url string, opts FetchOptionsModel) (jwkutil.JwksDocument, diag.Diagnostics) {
+	return fetchJwksDocumentWith(ctx, url, opts, func(ctx context.Context) (jwkutil.JwksDocument, error) {
+		return jwkutil.FetchJwks(ctx, client, url)
+	})
+}
+
+// fetchJwksDocumentWith is like fetchJwksDocument but uses get to fetch the
+// document published at url, for endpoints not serving a plain JWKS.
+func fetchJwksDocumentWith(ctx context.Context, url string, opts FetchOptionsModel, get jwksGetter) (jwkutil.JwksDocument, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	doc, err := waitForJwks(ctx, opts, get)
+	if err != nil {
+		diags.AddError("fetchJwks", fmt.Sprintf("Fail to fetch JWKs from %s : %s", url, err))
+		return jwkutil.JwksDocument{}, diags
+	}
+
+	if expected := opts.ExpectedSha256.ValueString(); expected != "" {
+		if digest := sha256Hex(doc.Raw); !strings.EqualFold(digest, expected) {
+			diags.AddError("fetchJwks", fmt.Sprintf("%s published a document with SHA-256 digest %s, expected %s", url, digest, expected))
+			return jwkutil.JwksDocument{}, diags
+		}
+	}
+
+	if opts.Strict.ValueBool() {
+		doc.Keys = strictJwks(doc.Keys, opts.DropInvalidKeys.ValueBool(), &diags)
+		if diags.HasError() {
+			return jwkutil.JwksDocument{}, diags
+		}
+	}
+
+	if minKeys := opts.MinKeys.ValueInt64(); int64(len(doc.Keys)) < minKeys {
+		diags.AddError("fetchJwks", fmt.Sprintf("%s published %d keys, expected at least %d", url, len(doc.Keys), minKeys))
+		return jwkutil.JwksDocument{}, diags
+	}
+
+	tflog.Debug(withRedaction(ctx), "Fetched JWKS", map[string]any{
+		"url":       url,
+		"key_count": len(doc.Keys),
+		"kids":      jwkutil.Kids(doc.Keys),
+	})
+	return doc, diags
+}
+
+// strictJwks parses every key with go-jose. Malformed keys are reported as
+// errors, or dropped with a warning when drop is set.
+func strictJwks(keys []json.RawMessage, drop bool, diags *diag.Diagnostics) []json.RawMessage {
+	var validKeys []json.RawMessage
+	for i, key := range keys {
+		var jwk jose.JSONWebKey
+		err := jwk.UnmarshalJSON(key)
+		if err == nil && !validKey(jwk) {
+			err = fmt.Errorf("invalid key material")
+		}
+		if err == nil {
+			validKeys = append(validKeys, key)
+			continue
+		}
+
+		if drop {
+			diags.AddWarning("strictJwks", fmt.Sprintf("Dropping malformed key #%d : %s", i, err))
+		} else {
+			diags.AddError("strictJwks", fmt.Sprintf("Malformed key #%d : %s", i, err))
+		}
+	}
+	return validKeys
+}
+
+// waitForJwks fetches a JWKS document with get, polling until wait_for_kid
+// shows up when it is set.
+func waitForJwks(ctx context.Context, opts FetchOptionsModel, get jwksGetter) (jwkutil.JwksDocument, error) {
+	kid := opts.WaitForKid.ValueString()
+	if kid == "" {
+		return get(ctx)
+	}
+
+	pollInterval, err := parseDuration(opts.PollInterval, defaultPollInterval)
+	if err != nil {
+		return jwkutil.JwksDocument{}, fmt.Errorf("invalid poll_interval: %s", err)
+	}
+	waitTimeout, err := parseDuration(opts.WaitTimeout, defaultWaitTimeout)
+	if err != nil {
+		return jwkutil.JwksDocument{}, fmt.Errorf("invalid wait_timeout: %s", err)
+	}
+
+	deadline := time.After(waitTimeout)
+	for {
+		doc, err := get(ctx)
+		if err == nil && hasKid(doc.Keys, kid) {
+			return doc, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return jwkutil.JwksDocument{}, ctx.Err()
+		case <-deadline:
+			if err != nil {
+				return jwkutil.JwksDocument{}, fmt.Errorf("kid %q not published after %s: %s", kid, waitTimeout, err)
+			}
+			return jwkutil.JwksDocument{}, fmt.Errorf("kid %q not published after %s", kid, waitTimeout)
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// postForm posts form to url and decodes the JSON response in v.
+func postForm(ctx context.Context, client *http.Client, url string, form url.Values, v any) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	respData, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return err
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s from %s: %s", httpResp.Status, url, strings.TrimSpace(string(respData)))
+	}
+
+	if err := json.Unmarshal(respData, v); err != nil {
+		return fmt.Errorf("can't unmarshal response from %s: %s", url, err)
+	}
+
+	return nil
+}
+
+// postJson posts in as JSON to url with the given headers and decodes the
+// JSON response in v.
+func postJson(ctx context.Context, client *http.Client, url string, headers map[string]string, in, v any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		httpReq.Header.Set(name, value)
+	}
+
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	respData, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return err
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s from %s: %s", httpResp.Status, url, strings.TrimSpace(string(respData)))
+	}
+
+	if err := json.Unmarshal(respData, v); err != nil {
+		return fmt.Errorf("can't unmarshal response from %s: %s", url, err)
+	}
+
+	return nil
+}
+
+// hasKid reports whether one of keys has the given kid.
+func hasKid(keys []json.RawMessage, kid string) bool {
+	for _, key := range keys {
+		if keyKid, err := jwkutil.Kid(key); err == nil && keyKid == kid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+const (
+	googleCloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+	googleMetadataTokenUrl   = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+	googleIssuer             = "https://accounts.google.com"
+	googleJwksUri            = "https://www.googleapis.com/oauth2/v3/certs"
+	googleX509Uri            = "https://www.googleapis.com/oauth2/v1/certs"
+	firebaseIssuerBase       = "https://securetoken.google.com/"
+	firebaseX509Uri          = "https://www.googleapis.com/robot/v1/metadata/x509/securetoken@system.gserviceaccount.com"
+)
+
+// GoogleCredentialsModel holds the attributes used to authenticate against
+// Google APIs. It is meant to be embedded in the data source model.
+type GoogleCredentialsModel struct {
+	AccessToken types.String `tfsdk:"access_token"`
+	Credentials types.String `tfsdk:"credentials"`
+}
+
+// GoogleCredentialsFile is the subset of a service account key or an
+// authorized user credentials file used to get an access token.
+type GoogleCredentialsFile struct {
+	Type         string `json:"type"`
+	ClientEmail  string `json:"client_email"`
+	ClientId     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	PrivateKey   string `json:"private_key"`
+	PrivateKeyId string `json:"private_key_id"`
+	RefreshToken string `json:"refresh_token"`
+	TokenUri     string `json:"token_uri"`
+}
+
+type GoogleTokenResp struct {
+	AccessToken string `json:"access_token"`
+}
+
+// withGoogleCredentialsAttributes adds the GoogleCredentialsModel attributes
+// to attrs.
+func withGoogleCredentialsAttributes(attrs map[string]schema.Attribute) map[string]schema.Attribute {
+	attrs["access_token"] = schema.StringAttribute{
+		MarkdownDescription: "Google OAuth2 access token, defaults to `GOOGLE_OAUTH_ACCESS_TOKEN`",
+		Optional:            true,
+		Sensitive:           true,
+	}
+	attrs["credentials"] = schema.StringAttribute{
+		MarkdownDescription: "Content of a service account key or authorized user credentials file, defaults to `GOOGLE_CREDENTIALS`, `GOOGLE_APPLICATION_CREDENTIALS`, the gcloud application default credentials and the metadata server",
+		Optional:            true,
+		Sensitive:           true,
+	}
+	return attrs
+}
+
+// accessToken resolves a Google OAuth2 access token for scope.
+func (m GoogleCredentialsModel) accessToken(ctx context.Context, client *http.Client, scope string) (string, error) {
+	if token := m.AccessToken.ValueString(); token != "" {
+		return token, nil
+	}
+	if token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+		return token, nil
+	}
+
+	credentials := m.Credentials.ValueString()
+	if credentials == "" {
+		credentials = os.Getenv("GOOGLE_CREDENTIALS")
+	}
+	if credentials == "" {
+		path := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+		if path == "" {
+			if configDir, err := os.UserConfigDir(); err == nil {
+				path = filepath.Join(configDir, "gcloud", "application_default_credentials.json")
+			}
+		}
+		if data, err := os.ReadFile(path); err == nil {
+			credentials = string(data)
+		}
+	}
+
+	if credentials == "" {
+		return googleMetadataAccessToken(ctx, client)
+	}
+
+	var credentialsFile GoogleCredentialsFile
+	if err := json.Unmarshal([]byte(credentials), &credentialsFile); err != nil {
+		return "", fmt.Errorf("can't unmarshal credentials: %s", err)
+	}
+	return credentialsFile.accessToken(ctx, client, scope)
+}
+
+// accessToken exchanges the credentials for an access token.
+func (c GoogleCredentialsFile) accessToken(ctx context.Context, client *http.Client, scope string) (string, error) {
+	tokenUri := c.TokenUri
+	if tokenUri == "" {
+		tokenUri = "https://oauth2.googleapis.com/token"
+	}
+
+	form := url.Values{}
+	switch c.Type {
+	case "service_account":
+		assertion, err := c.assertion(tokenUri, scope)
+		if err != nil {
+			return "", err
+		}
+		form.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+		form.Set("assertion", assertion)
+	case "authorized_user":
+		form.Set("grant_type", "refresh_token")
+		form.Set("client_id", c.ClientId)
+		form.Set("client_secret", c.ClientSecret)
+		form.Set("refresh_token", c.RefreshToken)
+	default:
+		return "", fmt.Errorf("unsupported credentials type %q", c.Type)
+	}
+
+	var tokenResp GoogleTokenResp
+	if err := postForm(ctx, client, tokenUri, form, &tokenResp); err != nil {
+		return "", err
+	}
+	return tokenResp.AccessToken, nil
+}
+
+// assertion builds the signed JWT exchanged for a service account token.
+func (c GoogleCredentialsFile) assertion(tokenUri, scope string) (string, error) {
+	block, _ := pem.Decode([]byte(c.PrivateKey))
+	if block == nil {
+		return "", fmt.Errorf("can't decode service account private key")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("can't parse service account private key: %s", err)
+	}
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: key, KeyID: c.PrivateKeyId}},
+		(&jose.SignerOptions{}).WithType("JWT"),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := map[string]any{
+		"iss":   c.ClientEmail,
+		"scope": scope,
+		"aud":   tokenUri,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}
+	return jwt.Signed(signer).Claims(claims).Serialize()
+}
+
+// googleMetadataAccessToken gets an access token from the GCE metadata
+// server.
+func googleMetadataAccessToken(ctx context.Context, client *http.Client) (string, error) {
+	var tokenResp GoogleTokenResp
+	err := jwkutil.GetJson(ctx, client, googleMetadataTokenUrl, map[string]string{"Metadata-Flavor": "Google"}, &tokenResp)
+	if err != nil {
+		return "", fmt.Errorf("no credentials configured: %s", err)
+	}
+	return tokenResp.AccessToken, nil
+}
+
+// getX509KeyMap fetches a map of key IDs to PEM certificates, the legacy
+// format Google uses to publish its signing keys, and returns it along with
+// the raw response.
+func getX509KeyMap(ctx context.Context, client *http.Client, url string) (map[string]string, []byte, error) {
+	respData, _, err := jwkutil.GetResponse(ctx, client, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var certs map[string]string
+	if err := json.Unmarshal(respData, &certs); err != nil {
+		return nil, nil, fmt.Errorf("can't unmarshal response from %s: %s", url, err)
+	}
+	return certs, respData, nil
+}
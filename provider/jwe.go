@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"fmt"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+// JweEncryptionModel holds the attributes used to encrypt a signed JWT into
+// a nested JWT. It is meant to be embedded in the data source model.
+type JweEncryptionModel struct {
+	Encryption          types.String `tfsdk:"encryption"`
+	EncryptionAlgorithm types.String `tfsdk:"encryption_algorithm"`
+	EncryptionJwk       types.String `tfsdk:"encryption_jwk"`
+}
+
+// withJweEncryptionAttributes adds the JweEncryptionModel attributes to attrs.
+func withJweEncryptionAttributes(attrs map[string]schema.Attribute) map[string]schema.Attribute {
+	attrs["encryption_jwk"] = schema.StringAttribute{
+		MarkdownDescription: "JWK of the recipient, when set the signed token is encrypted into a nested JWT",
+		Optional:            true,
+		Sensitive:           true,
+		Validators:          []validator.String{jwkValidator{}},
+	}
+	attrs["encryption_algorithm"] = schema.StringAttribute{
+		MarkdownDescription: "Key management algorithm, defaults to the `alg` of `encryption_jwk` or to the usual algorithm of its key type",
+		Optional:            true,
+	}
+	attrs["encryption"] = schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("Content encryption algorithm (default: `%s`)", jose.A256GCM),
+		Optional:            true,
+	}
+	return attrs
+}
+
+// encrypt encrypts the signed token to encryption_jwk, returning it as is
+// when encryption_jwk is not set. The encryption must comply with the policy
+// of p.
+func (m JweEncryptionModel) encrypt(p *JwkProviderData, token string) (string, error) {
+	if m.EncryptionJwk.IsNull() {
+		return token, nil
+	}
+
+	jwk, err := jwkutil.ParseJwk(m.EncryptionJwk.ValueString())
+	if err != nil {
+		return "", fmt.Errorf("can't unmarshal encryption_jwk: %s", err)
+	}
+
+	alg, err := jwkutil.KeyAlgorithm(jwk, m.EncryptionAlgorithm.ValueString())
+	if err != nil {
+		return "", err
+	}
+
+	enc := jose.A256GCM
+	if encryption := m.Encryption.ValueString(); encryption != "" {
+		enc = jose.ContentEncryption(encryption)
+	}
+	if err := p.checkEncryptionKey(jwk, alg, enc); err != nil {
+		return "", err
+	}
+
+	encrypter, err := newJweEncrypter(jwk, alg, enc, "JWT")
+	if err != nil {
+		return "", err
+	}
+
+	jwe, err := encrypter.Encrypt([]byte(token))
+	if err != nil {
+		return "", err
+	}
+	return jwe.CompactSerialize()
+}
+
+// newJweEncrypter returns an encrypter to the recipient jwk.
+func newJweEncrypter(jwk jose.JSONWebKey, alg jose.KeyAlgorithm, enc jose.ContentEncryption, contentType string) (jose.Encrypter, error) {
+	opts := &jose.EncrypterOptions{}
+	if contentType != "" {
+		opts = opts.WithContentType(jose.ContentType(contentType))
+	}
+
+	recipient := jose.Recipient{
+		Algorithm: alg,
+		Key:       jwkutil.PublicKey(jwk).Key,
+		KeyID:     jwk.KeyID,
+	}
+	return jose.NewEncrypter(enc, recipient, opts)
+}
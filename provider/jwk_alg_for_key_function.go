@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ function.Function = &JwkAlgForKeyFunction{}
+
+type JwkAlgForKeyFunction struct{}
+
+func NewJwkAlgForKeyFunction() function.Function {
+	return &JwkAlgForKeyFunction{}
+}
+
+func (f *JwkAlgForKeyFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "alg_for_key"
+}
+
+func (f *JwkAlgForKeyFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Recommended JWS algorithm of a JWK",
+		MarkdownDescription: "Returns the recommended JWS algorithm of a JWK from its key type, curve and size, ignoring its `alg` member: `RS256` for RSA keys, `ES256`, `ES384` or `ES512` for EC keys, `EdDSA` for OKP keys and `HS256`, `HS384` or `HS512` for symmetric keys, the strongest one their size allows",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "jwk",
+				MarkdownDescription: "JWK",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *JwkAlgForKeyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var jwkStr string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &jwkStr))
+	if resp.Error != nil {
+		return
+	}
+
+	jwk, err := jwkutil.ParseJwk(jwkStr)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, withJwkHint(fmt.Sprintf("Can't unmarshal JWK : %s", err), jwkStr, false))
+		return
+	}
+
+	var alg jose.SignatureAlgorithm
+	if key, ok := jwk.Key.([]byte); ok {
+		alg, err = hmacAlgorithm(len(key) * 8)
+	} else {
+		jwk.Algorithm = ""
+		alg, err = jwkutil.SignatureAlgorithm(jwk, "")
+	}
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Can't infer the algorithm of the JWK : %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, string(alg)))
+}
+
+// hmacAlgorithm returns the strongest HMAC algorithm a key of bits bits is
+// long enough for.
+func hmacAlgorithm(bits int) (jose.SignatureAlgorithm, error) {
+	for _, alg := range []jose.SignatureAlgorithm{jose.HS512, jose.HS384, jose.HS256} {
+		if bits >= hmacMinBits[string(alg)] {
+			return alg, nil
+		}
+	}
+	return "", fmt.Errorf("symmetric key of %d bits is too short for HS256", bits)
+}
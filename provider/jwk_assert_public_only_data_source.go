@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ datasource.DataSource = &JwkAssertPublicOnlyDataSource{}
+
+type JwkAssertPublicOnlyDataSource struct{}
+
+type JwkAssertPublicOnlyDataSourceModel struct {
+	Id         types.String   `tfsdk:"id"`
+	Jwk        types.String   `tfsdk:"jwk"`
+	Jwks       types.List     `tfsdk:"jwks"`
+	PublicJwks types.String   `tfsdk:"public_jwks"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewJwkAssertPublicOnlyDataSource() datasource.DataSource {
+	return &JwkAssertPublicOnlyDataSource{}
+}
+
+func (d *JwkAssertPublicOnlyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_assert_public_only"
+}
+
+func (d *JwkAssertPublicOnlyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used as a gate before publishing keys: it fails when one of them holds private or symmetric key material (`d`, `p`, `q`, `dp`, `dq`, `qi`, `oth` or `k`)",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"jwk": schema.StringAttribute{
+				MarkdownDescription: "JWK or JWKS to check, conflicts with `jwks`",
+				Optional:            true,
+				Sensitive:           true,
+				Validators:          []validator.String{jwkValidator{jwks: true}},
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKs to check, conflicts with `jwk`",
+				Optional:            true,
+				Sensitive:           true,
+				Validators:          []validator.List{jwkListValidator{}},
+			},
+			"public_jwks": schema.StringAttribute{
+				MarkdownDescription: "JWKS of the checked keys, not sensitive",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkAssertPublicOnlyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+}
+
+func (d *JwkAssertPublicOnlyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkAssertPublicOnlyDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, err := keySet(data.Jwk, data.Jwks)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(keySetPath(data.Jwk), "keySet", withJwkHint(fmt.Sprintf("Can't read keys : %s", err), data.Jwk.ValueString(), true))
+		return
+	}
+
+	for i, key := range keys {
+		if members := privateMembers(key); len(members) > 0 {
+			detail := fmt.Sprintf("Key %d holds private key material : %s", i, strings.Join(members, ", "))
+			if kid, err := jwkutil.Kid(key); err == nil && kid != "" {
+				detail = fmt.Sprintf("Key %q holds private key material : %s", kid, strings.Join(members, ", "))
+			}
+			resp.Diagnostics.AddAttributeError(keySetIndexPath(data.Jwk, i), "Private key material", detail)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jwksData, err := json.Marshal(JwksResp{Keys: keys})
+	if err != nil {
+		resp.Diagnostics.AddError("Marshal", fmt.Sprintf("Can't marshal JwksResp : %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(sha256Hex(jwksData))
+	data.PublicJwks = types.StringValue(string(jwksData))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ datasource.DataSource = &JwkAuditReportDataSource{}
+
+type JwkAuditReportDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkAuditReportDataSourceModel struct {
+	Compliant types.Bool     `tfsdk:"compliant"`
+	Id        types.String   `tfsdk:"id"`
+	Jwk       types.String   `tfsdk:"jwk"`
+	Jwks      types.List     `tfsdk:"jwks"`
+	Report    types.String   `tfsdk:"report"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+// auditReport is the JSON document of the report attribute.
+type auditReport struct {
+	Compliant bool       `json:"compliant"`
+	KeyCount  int        `json:"key_count"`
+	Keys      []auditKey `json:"keys"`
+}
+
+// auditKey is the audit of a single key of an auditReport, holding no key
+// material. It holds no durations either, which would change the report on
+// every plan.
+type auditKey struct {
+	Algorithm        string   `json:"alg,omitempty"`
+	Compliant        bool     `json:"compliant"`
+	Errors           []string `json:"errors"`
+	Expired          bool     `json:"expired"`
+	ExpiresAt        string   `json:"expires_at,omitempty"`
+	Index            int      `json:"index"`
+	Kid              string   `json:"kid,omitempty"`
+	Kty              string   `json:"kty,omitempty"`
+	PolicyViolations []string `json:"policy_violations"`
+	Private          bool     `json:"private"`
+	Size             int      `json:"size,omitempty"`
+	Status           string   `json:"status,omitempty"`
+	Strength         int      `json:"strength,omitempty"`
+	Thumbprint       string   `json:"thumbprint,omitempty"`
+	Use              string   `json:"use,omitempty"`
+	Warnings         []string `json:"warnings"`
+}
+
+func NewJwkAuditReportDataSource() datasource.DataSource {
+	return &JwkAuditReportDataSource{}
+}
+
+func (d *JwkAuditReportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_audit_report"
+}
+
+func (d *JwkAuditReportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to produce a JSON audit report of keys, to be shipped from a Terraform output to SIEM or compliance pipelines. For every key, the report holds its `kid`, `kty`, `alg`, `use` and RFC 7638 `thumbprint`, its `size` and NIST SP 800-57 `strength` and `status`, its expiry from its `exp` member or its `x5c` certificate, the `errors` and `warnings` of `jwk_validate` and the `policy_violations` of the provider policy (`fips_mode`, `min_rsa_bits`, `allowed_curves` and `banned_algorithms`). The report holds no key material",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"jwk": schema.StringAttribute{
+				MarkdownDescription: "JWK or JWKS to audit, conflicts with `jwks`",
+				Optional:            true,
+				Sensitive:           true,
+				Validators:          []validator.String{jwkValidator{jwks: true}},
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKs to audit, conflicts with `jwk`",
+				Optional:            true,
+				Sensitive:           true,
+				Validators:          []validator.List{jwkListValidator{}},
+			},
+			"report": schema.StringAttribute{
+				MarkdownDescription: "JSON audit report, not sensitive",
+				Computed:            true,
+			},
+			"compliant": schema.BoolAttribute{
+				MarkdownDescription: "Whether no key has errors or policy violations, or is expired",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkAuditReportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkAuditReportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkAuditReportDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, err := keySet(data.Jwk, data.Jwks)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(keySetPath(data.Jwk), "keySet", withJwkHint(fmt.Sprintf("Can't read keys : %s", err), data.Jwk.ValueString(), true))
+		return
+	}
+
+	now := time.Now()
+	report := auditReport{
+		Compliant: true,
+		KeyCount:  len(keys),
+		Keys:      []auditKey{},
+	}
+	for i, key := range keys {
+		audit := d.auditKey(key, now)
+		audit.Index = i
+		report.Compliant = report.Compliant && audit.Compliant
+		report.Keys = append(report.Keys, audit)
+	}
+
+	reportData, err := json.Marshal(report)
+	if err != nil {
+		resp.Diagnostics.AddError("Marshal", fmt.Sprintf("Can't marshal auditReport : %s", err))
+		return
+	}
+	jwksData, err := json.Marshal(JwksResp{Keys: keys})
+	if err != nil {
+		resp.Diagnostics.AddError("Marshal", fmt.Sprintf("Can't marshal JwksResp : %s", err))
+		return
+	}
+
+	data.Compliant = types.BoolValue(report.Compliant)
+	data.Id = types.StringValue(sha256Hex(jwksData))
+	data.Report = types.StringValue(string(reportData))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// auditKey returns the audit of key at now, its index left unset.
+func (d *JwkAuditReportDataSource) auditKey(key json.RawMessage, now time.Time) auditKey {
+	validation := validateJwk(string(key), false)
+	audit := auditKey{
+		Errors:           append([]string{}, validation.errors...),
+		PolicyViolations: []string{},
+		Private:          len(privateMembers(key)) > 0,
+		Thumbprint:       validation.thumbprint,
+		Warnings:         append([]string{}, validation.warnings...),
+	}
+
+	var members struct {
+		Kty string `json:"kty"`
+	}
+	if err := json.Unmarshal(key, &members); err == nil {
+		audit.Kty = members.Kty
+	}
+
+	if jwk, err := jwkutil.ParseJwk(string(key)); err == nil {
+		audit.Algorithm = jwk.Algorithm
+		audit.Kid = jwk.KeyID
+		audit.Use = jwk.Use
+		if bits, strength, ok := keyStrength(jwk.Key); ok {
+			level := strengthLevel(strength)
+			audit.Size = bits
+			audit.Status = strengthStatus(level)
+			audit.Strength = level
+		}
+		audit.PolicyViolations = append(audit.PolicyViolations, d.provider.policyViolations(jwk)...)
+	}
+
+	if expiry, ok := keyExpiry(key); ok {
+		audit.Expired = expiry.Before(now)
+		audit.ExpiresAt = expiry.UTC().Format(time.RFC3339)
+	}
+
+	audit.Compliant = len(audit.Errors) == 0 && len(audit.PolicyViolations) == 0 && !audit.Expired
+	return audit
+}
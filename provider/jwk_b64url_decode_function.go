@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &JwkB64urlDecodeFunction{}
+
+type JwkB64urlDecodeFunction struct{}
+
+func NewJwkB64urlDecodeFunction() function.Function {
+	return &JwkB64urlDecodeFunction{}
+}
+
+func (f *JwkB64urlDecodeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "b64url_decode"
+}
+
+func (f *JwkB64urlDecodeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Decode a base64url string",
+		MarkdownDescription: "Returns the string encoded in base64url, padded or not. It fails when the decoded bytes aren't valid UTF-8, as Terraform strings must be",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "value",
+				MarkdownDescription: "base64url string to decode",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *JwkB64urlDecodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Can't decode base64url : %s", err))
+		return
+	}
+	if !utf8.Valid(decoded) {
+		resp.Error = function.NewArgumentFuncError(0, "The decoded bytes aren't valid UTF-8")
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, string(decoded)))
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &JwkB64urlEncodeFunction{}
+
+type JwkB64urlEncodeFunction struct{}
+
+func NewJwkB64urlEncodeFunction() function.Function {
+	return &JwkB64urlEncodeFunction{}
+}
+
+func (f *JwkB64urlEncodeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "b64url_encode"
+}
+
+func (f *JwkB64urlEncodeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Encode a string to base64url",
+		MarkdownDescription: "Returns the unpadded base64url encoding of the UTF-8 bytes of a string, as used by JOSE",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "value",
+				MarkdownDescription: "String to encode",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *JwkB64urlEncodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, base64.RawURLEncoding.EncodeToString([]byte(value))))
+}
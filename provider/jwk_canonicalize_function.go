@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ function.Function = &JwkCanonicalizeFunction{}
+
+type JwkCanonicalizeFunction struct{}
+
+func NewJwkCanonicalizeFunction() function.Function {
+	return &JwkCanonicalizeFunction{}
+}
+
+func (f *JwkCanonicalizeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "canonicalize"
+}
+
+func (f *JwkCanonicalizeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Canonical form of a JWK or a JWKS",
+		MarkdownDescription: "Returns the minimal JSON of a JWK or a JWKS, with unknown members dropped and known members in a fixed order, so that formatting differences don't change its value",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "jwk",
+				MarkdownDescription: "JWK or JWKS",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *JwkCanonicalizeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var jwkStr string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &jwkStr))
+	if resp.Error != nil {
+		return
+	}
+
+	keys, err := jwkutil.ParseJwks([]byte(jwkStr))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, withJwkHint(fmt.Sprintf("Can't parse JWK : %s", err), jwkStr, true))
+		return
+	}
+
+	canonicalKeys := []json.RawMessage{}
+	for i, key := range keys {
+		canonical, err := canonicalKey(key)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Can't unmarshal key #%d : %s", i, err))
+			return
+		}
+		canonicalKeys = append(canonicalKeys, canonical)
+	}
+
+	// ParseJwks returns a single JWK as is, a JWKS is any other document.
+	var canonical []byte
+	if len(keys) == 1 && string(keys[0]) == jwkStr {
+		canonical = canonicalKeys[0]
+	} else {
+		canonical, err = json.Marshal(JwksResp{Keys: canonicalKeys})
+		if err != nil {
+			resp.Error = function.NewFuncError(fmt.Sprintf("Can't marshal JwksResp : %s", err))
+			return
+		}
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, string(canonical)))
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ datasource.DataSource = &JwkConfirmationDataSource{}
+
+type JwkConfirmationDataSource struct{}
+
+type JwkConfirmationDataSourceModel struct {
+	Cnf      types.String   `tfsdk:"cnf"`
+	Id       types.String   `tfsdk:"id"`
+	Jkt      types.String   `tfsdk:"jkt"`
+	Jwk      types.String   `tfsdk:"jwk"`
+	Method   types.String   `tfsdk:"method"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	X5tS256  types.String   `tfsdk:"x5t_s256"`
+}
+
+func NewJwkConfirmationDataSource() datasource.DataSource {
+	return &JwkConfirmationDataSource{}
+}
+
+func (d *JwkConfirmationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_confirmation"
+}
+
+func (d *JwkConfirmationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to compute the confirmation claim binding a token to a key, for DPoP (RFC 9449) or certificate bound (RFC 8705) tokens",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"jwk": schema.StringAttribute{
+				MarkdownDescription: "JWK the token is bound to, only its public part is used",
+				Required:            true,
+				Sensitive:           true,
+				Validators:          []validator.String{jwkValidator{}},
+			},
+			"method": schema.StringAttribute{
+				MarkdownDescription: "Confirmation method of `cnf`, `jkt` or `x5t#S256` (default: `jkt`)",
+				Optional:            true,
+			},
+			"jkt": schema.StringAttribute{
+				MarkdownDescription: "Base64url SHA-256 thumbprint of the JWK (RFC 7638)",
+				Computed:            true,
+			},
+			"x5t_s256": schema.StringAttribute{
+				MarkdownDescription: "Base64url SHA-256 thumbprint of the first certificate of the `x5c` chain of the JWK, null without chain",
+				Computed:            true,
+			},
+			"cnf": schema.StringAttribute{
+				MarkdownDescription: "JSON encoded `cnf` claim",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkConfirmationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+}
+
+func (d *JwkConfirmationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkConfirmationDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jwk, err := jwkutil.ParseJwk(data.Jwk.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "ParseJwk", withJwkHint(fmt.Sprintf("Can't unmarshal JWK : %s", err), data.Jwk.ValueString(), false))
+		return
+	}
+
+	public := jwkutil.PublicKey(jwk)
+	thumbprint, err := public.Thumbprint(crypto.SHA256)
+	if err != nil {
+		resp.Diagnostics.AddError("Thumbprint", fmt.Sprintf("Can't compute thumbprint : %s", err))
+		return
+	}
+	jkt := base64.RawURLEncoding.EncodeToString(thumbprint)
+
+	data.X5tS256 = types.StringNull()
+	if len(jwk.Certificates) > 0 {
+		sum := sha256.Sum256(jwk.Certificates[0].Raw)
+		data.X5tS256 = types.StringValue(base64.RawURLEncoding.EncodeToString(sum[:]))
+	}
+
+	var cnf map[string]string
+	switch method := data.Method.ValueString(); method {
+	case "", "jkt":
+		cnf = map[string]string{"jkt": jkt}
+	case "x5t#S256":
+		if data.X5tS256.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("method"), "method", "The x5t#S256 method requires a JWK with an x5c certificate chain")
+			return
+		}
+		cnf = map[string]string{"x5t#S256": data.X5tS256.ValueString()}
+	default:
+		resp.Diagnostics.AddAttributeError(path.Root("method"), "method", fmt.Sprintf("Unsupported confirmation method %q, expected jkt or x5t#S256", method))
+		return
+	}
+
+	cnfJson, err := json.Marshal(cnf)
+	if err != nil {
+		resp.Diagnostics.AddError("Marshal", fmt.Sprintf("Can't marshal cnf : %s", err))
+		return
+	}
+
+	data.Cnf = types.StringValue(string(cnfJson))
+	data.Id = types.StringValue(jkt)
+	data.Jkt = types.StringValue(jkt)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &JwkDecodeJwtFunction{}
+
+type JwkDecodeJwtFunction struct{}
+
+func NewJwkDecodeJwtFunction() function.Function {
+	return &JwkDecodeJwtFunction{}
+}
+
+func (f *JwkDecodeJwtFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "decode_jwt"
+}
+
+func (f *JwkDecodeJwtFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Decode a JWT without verifying it",
+		MarkdownDescription: "Returns an object with the `header` and the `claims` of a JWT, decoded without verifying its signature. Use the `jwk_jwt_verify` data source to trust them",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "token",
+				MarkdownDescription: "JWT",
+			},
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (f *JwkDecodeJwtFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var token string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &token))
+	if resp.Error != nil {
+		return
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Token has %d parts, expected 3", len(parts)))
+		return
+	}
+
+	header, err := decodeJwtPart(ctx, parts[0])
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Can't decode header : %s", err))
+		return
+	}
+	claims, err := decodeJwtPart(ctx, parts[1])
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Can't decode claims : %s", err))
+		return
+	}
+
+	decoded, diags := types.ObjectValue(
+		map[string]attr.Type{"claims": claims.Type(ctx), "header": header.Type(ctx)},
+		map[string]attr.Value{"claims": claims, "header": header},
+	)
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, types.DynamicValue(decoded)))
+}
+
+// decodeJwtPart decodes a base64url encoded JSON object of a JWT.
+func decodeJwtPart(ctx context.Context, part string) (attr.Value, error) {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var object map[string]any
+	if err := decoder.Decode(&object); err != nil {
+		return nil, err
+	}
+	return jsonValue(ctx, object)
+}
+
+// jsonValue converts a JSON value decoded with UseNumber to a Terraform
+// value, objects becoming objects and arrays tuples.
+func jsonValue(ctx context.Context, value any) (attr.Value, error) {
+	switch v := value.(type) {
+	case nil:
+		return types.StringNull(), nil
+	case bool:
+		return types.BoolValue(v), nil
+	case string:
+		return types.StringValue(v), nil
+	case json.Number:
+		number, _, err := big.ParseFloat(v.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse number %s: %s", v, err)
+		}
+		return types.NumberValue(number), nil
+	case []any:
+		elemTypes := make([]attr.Type, 0, len(v))
+		elems := make([]attr.Value, 0, len(v))
+		for _, elem := range v {
+			elemValue, err := jsonValue(ctx, elem)
+			if err != nil {
+				return nil, err
+			}
+			elemTypes = append(elemTypes, elemValue.Type(ctx))
+			elems = append(elems, elemValue)
+		}
+		tuple, diags := types.TupleValue(elemTypes, elems)
+		if diags.HasError() {
+			return nil, fmt.Errorf("can't build tuple: %v", diags)
+		}
+		return tuple, nil
+	case map[string]any:
+		attrTypes := make(map[string]attr.Type, len(v))
+		attrs := make(map[string]attr.Value, len(v))
+		for name, member := range v {
+			memberValue, err := jsonValue(ctx, member)
+			if err != nil {
+				return nil, err
+			}
+			attrTypes[name] = memberValue.Type(ctx)
+			attrs[name] = memberValue
+		}
+		object, diags := types.ObjectValue(attrTypes, attrs)
+		if diags.HasError() {
+			return nil, fmt.Errorf("can't build object: %v", diags)
+		}
+		return object, nil
+	default:
+		return nil, fmt.Errorf("unsupported JSON value %T", value)
+	}
+}
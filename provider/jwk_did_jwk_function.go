@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ function.Function = &JwkDidJwkFunction{}
+
+type JwkDidJwkFunction struct{}
+
+func NewJwkDidJwkFunction() function.Function {
+	return &JwkDidJwkFunction{}
+}
+
+func (f *JwkDidJwkFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "did_jwk"
+}
+
+func (f *JwkDidJwkFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "did:jwk identifier of a JWK",
+		MarkdownDescription: "Returns the `did:jwk:` identifier of the public form of a JWK, the base64url encoding of its JSON. Symmetric keys are rejected",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "jwk",
+				MarkdownDescription: "JWK",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *JwkDidJwkFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var jwkStr string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &jwkStr))
+	if resp.Error != nil {
+		return
+	}
+
+	jwk, err := jwkutil.ParseJwk(jwkStr)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, withJwkHint(fmt.Sprintf("Can't unmarshal JWK : %s", err), jwkStr, false))
+		return
+	}
+	if _, ok := jwk.Key.([]byte); ok {
+		resp.Error = function.NewArgumentFuncError(0, "Symmetric keys have no did:jwk identifier")
+		return
+	}
+
+	jwkData, err := jwkutil.PublicKey(jwk).MarshalJSON()
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Can't marshal JWK : %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, "did:jwk:"+base64.RawURLEncoding.EncodeToString(jwkData)))
+}
@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ datasource.DataSource = &JwkFromAksDataSource{}
+
+type JwkFromAksDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkFromAksDataSourceModel struct {
+	AzureCredentialsModel
+	FetchOptionsModel
+	ClusterName       types.String   `tfsdk:"cluster_name"`
+	Id                types.String   `tfsdk:"id"`
+	Issuer            types.String   `tfsdk:"issuer"`
+	Jwks              types.List     `tfsdk:"jwks"`
+	Keys              types.List     `tfsdk:"keys"`
+	JwksUri           types.String   `tfsdk:"jwks_uri"`
+	ResourceGroupName types.String   `tfsdk:"resource_group_name"`
+	SubscriptionId    types.String   `tfsdk:"subscription_id"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+type AksManagedClusterResp struct {
+	Id         string `json:"id"`
+	Properties struct {
+		OidcIssuerProfile struct {
+			Enabled   bool   `json:"enabled"`
+			IssuerURL string `json:"issuerURL"`
+		} `json:"oidcIssuerProfile"`
+	} `json:"properties"`
+}
+
+func NewJwkFromAksDataSource() datasource.DataSource {
+	return &JwkFromAksDataSource{}
+}
+
+func (d *JwkFromAksDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_from_aks"
+}
+
+func (d *JwkFromAksDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to fetch the JWKs of an AKS cluster OIDC issuer",
+
+		Attributes: withFetchOptionsAttributes(withAzureCredentialsAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"subscription_id": schema.StringAttribute{
+				MarkdownDescription: "Azure subscription ID of the cluster, defaults to `ARM_SUBSCRIPTION_ID`",
+				Optional:            true,
+			},
+			"resource_group_name": schema.StringAttribute{
+				MarkdownDescription: "Resource group of the cluster",
+				Required:            true,
+			},
+			"cluster_name": schema.StringAttribute{
+				MarkdownDescription: "AKS cluster name",
+				Required:            true,
+			},
+			"issuer": schema.StringAttribute{
+				MarkdownDescription: "OIDC issuer URL of the cluster",
+				Computed:            true,
+			},
+			"jwks_uri": schema.StringAttribute{
+				MarkdownDescription: "JWKS URI of the cluster",
+				Computed:            true,
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKs",
+				Computed:            true,
+			},
+			"keys": keysAttribute(false),
+		})),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkFromAksDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkFromAksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkFromAksDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subscriptionId := valueOrEnv(data.SubscriptionId, "ARM_SUBSCRIPTION_ID")
+	if subscriptionId == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("subscription_id"), "subscription_id", "No Azure subscription configured")
+		return
+	}
+
+	client := d.provider.newHTTPClient(nil)
+
+	token, err := data.accessToken(ctx, client, azureManagementResource)
+	if err != nil {
+		resp.Diagnostics.AddError("accessToken", fmt.Sprintf("Can't get Azure access token : %s", err))
+		return
+	}
+
+	clusterUrl := fmt.Sprintf(
+		"%s/subscriptions/%s/resourceGroups/%s/providers/Microsoft.ContainerService/managedClusters/%s?api-version=2024-02-01",
+		azureManagementResource,
+		url.PathEscape(subscriptionId),
+		url.PathEscape(data.ResourceGroupName.ValueString()),
+		url.PathEscape(data.ClusterName.ValueString()),
+	)
+
+	var aksResp AksManagedClusterResp
+	err = jwkutil.GetJson(ctx, client, clusterUrl, map[string]string{"Authorization": "Bearer " + token}, &aksResp)
+	if err != nil {
+		resp.Diagnostics.AddError("GetManagedCluster", fmt.Sprintf("Fail to get AKS cluster : %s", err))
+		return
+	}
+
+	issuer := aksResp.Properties.OidcIssuerProfile.IssuerURL
+	if !aksResp.Properties.OidcIssuerProfile.Enabled || issuer == "" {
+		resp.Diagnostics.AddError("GetManagedCluster", fmt.Sprintf("AKS cluster %s has no OIDC issuer enabled", data.ClusterName.ValueString()))
+		return
+	}
+
+	discovery, keys, diags := fetchOidcJwks(ctx, client, issuer, data.FetchOptionsModel)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, data.Jwks, data.Keys = d.provider.finishKeys(&resp.Diagnostics, path.Empty(), keys)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(aksResp.Id)
+	data.Issuer = types.StringValue(issuer)
+	data.JwksUri = types.StringValue(discovery.JwksUri)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const appleIssuer = "https://appleid.apple.com"
+
+var _ datasource.DataSource = &JwkFromAppleDataSource{}
+
+type JwkFromAppleDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkFromAppleDataSourceModel struct {
+	FetchOptionsModel
+	Id                types.String   `tfsdk:"id"`
+	Issuer            types.String   `tfsdk:"issuer"`
+	Jwks              types.List     `tfsdk:"jwks"`
+	Keys              types.List     `tfsdk:"keys"`
+	JwksUri           types.String   `tfsdk:"jwks_uri"`
+	SigningAlgorithms types.List     `tfsdk:"signing_algorithms"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewJwkFromAppleDataSource() datasource.DataSource {
+	return &JwkFromAppleDataSource{}
+}
+
+func (d *JwkFromAppleDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_from_apple"
+}
+
+func (d *JwkFromAppleDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to fetch the issuer metadata and public keys of Sign in with Apple",
+
+		Attributes: withFetchOptionsAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"issuer": schema.StringAttribute{
+				MarkdownDescription: "Issuer of the Apple ID tokens",
+				Computed:            true,
+			},
+			"jwks_uri": schema.StringAttribute{
+				MarkdownDescription: "JWKS URI",
+				Computed:            true,
+			},
+			"signing_algorithms": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Algorithms used to sign the ID tokens",
+				Computed:            true,
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKs",
+				Computed:            true,
+			},
+			"keys": keysAttribute(false),
+		}),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkFromAppleDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkFromAppleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkFromAppleDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	discovery, keys, diags := fetchOidcJwks(ctx, d.provider.newHTTPClient(nil), appleIssuer, data.FetchOptionsModel)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, data.Jwks, data.Keys = d.provider.finishKeys(&resp.Diagnostics, path.Empty(), keys)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.SigningAlgorithms, diags = types.ListValueFrom(ctx, types.StringType, discovery.IdTokenSigningAlgValuesSupported)
+	resp.Diagnostics.Append(diags...)
+	data.Id = types.StringValue(appleIssuer)
+	data.Issuer = types.StringValue(discovery.Issuer)
+	data.JwksUri = types.StringValue(discovery.JwksUri)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const circleciIssuerBase = "https://oidc.circleci.com/org/"
+
+var _ datasource.DataSource = &JwkFromCircleciDataSource{}
+
+type JwkFromCircleciDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkFromCircleciDataSourceModel struct {
+	FetchOptionsModel
+	Id       types.String   `tfsdk:"id"`
+	Issuer   types.String   `tfsdk:"issuer"`
+	Jwks     types.List     `tfsdk:"jwks"`
+	Keys     types.List     `tfsdk:"keys"`
+	JwksUri  types.String   `tfsdk:"jwks_uri"`
+	OrgId    types.String   `tfsdk:"org_id"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewJwkFromCircleciDataSource() datasource.DataSource {
+	return &JwkFromCircleciDataSource{}
+}
+
+func (d *JwkFromCircleciDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_from_circleci"
+}
+
+func (d *JwkFromCircleciDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to fetch the issuer and JWKs of the CircleCI OIDC provider of an organization",
+
+		Attributes: withFetchOptionsAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "CircleCI organization ID",
+				Required:            true,
+			},
+			"issuer": schema.StringAttribute{
+				MarkdownDescription: "Issuer of the CircleCI tokens",
+				Computed:            true,
+			},
+			"jwks_uri": schema.StringAttribute{
+				MarkdownDescription: "JWKS URI",
+				Computed:            true,
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKs",
+				Computed:            true,
+			},
+			"keys": keysAttribute(false),
+		}),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkFromCircleciDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkFromCircleciDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkFromCircleciDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	issuer := circleciIssuerBase + url.PathEscape(data.OrgId.ValueString())
+
+	discovery, keys, diags := fetchOidcJwks(ctx, d.provider.newHTTPClient(nil), issuer, data.FetchOptionsModel)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, data.Jwks, data.Keys = d.provider.finishKeys(&resp.Diagnostics, path.Empty(), keys)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(issuer)
+	data.Issuer = types.StringValue(discovery.Issuer)
+	data.JwksUri = types.StringValue(discovery.JwksUri)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &JwkFromCognitoDataSource{}
+
+type JwkFromCognitoDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkFromCognitoDataSourceModel struct {
+	FetchOptionsModel
+	Id         types.String   `tfsdk:"id"`
+	Issuer     types.String   `tfsdk:"issuer"`
+	Jwks       types.List     `tfsdk:"jwks"`
+	Keys       types.List     `tfsdk:"keys"`
+	JwksUri    types.String   `tfsdk:"jwks_uri"`
+	Region     types.String   `tfsdk:"region"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+	UserPoolId types.String   `tfsdk:"user_pool_id"`
+}
+
+func NewJwkFromCognitoDataSource() datasource.DataSource {
+	return &JwkFromCognitoDataSource{}
+}
+
+func (d *JwkFromCognitoDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_from_cognito"
+}
+
+func (d *JwkFromCognitoDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to fetch the issuer and JWKs of an Amazon Cognito user pool",
+
+		Attributes: withFetchOptionsAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"user_pool_id": schema.StringAttribute{
+				MarkdownDescription: "Cognito user pool ID",
+				Required:            true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "AWS region of the user pool, defaults to the prefix of `user_pool_id`",
+				Optional:            true,
+			},
+			"issuer": schema.StringAttribute{
+				MarkdownDescription: "Issuer of the user pool tokens",
+				Computed:            true,
+			},
+			"jwks_uri": schema.StringAttribute{
+				MarkdownDescription: "JWKS URI",
+				Computed:            true,
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKs",
+				Computed:            true,
+			},
+			"keys": keysAttribute(false),
+		}),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkFromCognitoDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkFromCognitoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkFromCognitoDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userPoolId := data.UserPoolId.ValueString()
+	region := data.Region.ValueString()
+	if region == "" {
+		var found bool
+		region, _, found = strings.Cut(userPoolId, "_")
+		if !found {
+			resp.Diagnostics.AddAttributeError(path.Root("region"), "region", fmt.Sprintf("Can't infer region from user pool ID %s", userPoolId))
+			return
+		}
+	}
+
+	issuer := fmt.Sprintf("https://cognito-idp.%s.amazonaws.com/%s", region, url.PathEscape(userPoolId))
+	jwksUri := issuer + "/.well-known/jwks.json"
+
+	client, err := data.fetchClient(d.provider.newHTTPClient(nil))
+	if err != nil {
+		resp.Diagnostics.AddError("fetchClient", fmt.Sprintf("Can't configure HTTP client : %s", err))
+		return
+	}
+
+	keys, diags := fetchJwks(ctx, client, jwksUri, data.FetchOptionsModel)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, data.Jwks, data.Keys = d.provider.finishKeys(&resp.Diagnostics, path.Empty(), keys)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(issuer)
+	data.Issuer = types.StringValue(issuer)
+	data.JwksUri = types.StringValue(jwksUri)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
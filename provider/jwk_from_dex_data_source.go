@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ datasource.DataSource = &JwkFromDexDataSource{}
+
+type JwkFromDexDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkFromDexDataSourceModel struct {
+	FetchOptionsModel
+	CaCertificate    types.String   `tfsdk:"ca_certificate"`
+	Id               types.String   `tfsdk:"id"`
+	Issuer           types.String   `tfsdk:"issuer"`
+	Jwks             types.List     `tfsdk:"jwks"`
+	Keys             types.List     `tfsdk:"keys"`
+	JwksUri          types.String   `tfsdk:"jwks_uri"`
+	NextRotation     types.String   `tfsdk:"next_rotation"`
+	SigningKid       types.String   `tfsdk:"signing_kid"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+	VerificationKids types.List     `tfsdk:"verification_kids"`
+}
+
+func NewJwkFromDexDataSource() datasource.DataSource {
+	return &JwkFromDexDataSource{}
+}
+
+func (d *JwkFromDexDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_from_dex"
+}
+
+func (d *JwkFromDexDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to fetch the JWKs of a Dex instance, telling apart the signing key from the keys kept for verification after a rotation",
+
+		Attributes: withFetchOptionsAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"issuer": schema.StringAttribute{
+				MarkdownDescription: "Issuer URL of the Dex instance",
+				Required:            true,
+			},
+			"ca_certificate": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificate of the Dex instance, the system roots are used when unset",
+				Optional:            true,
+				Validators:          []validator.String{pemValidator{}},
+			},
+			"jwks_uri": schema.StringAttribute{
+				MarkdownDescription: "JWKS URI",
+				Computed:            true,
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKs",
+				Computed:            true,
+			},
+			"keys": keysAttribute(false),
+			"signing_kid": schema.StringAttribute{
+				MarkdownDescription: "kid of the key currently used to sign tokens",
+				Computed:            true,
+			},
+			"verification_kids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "kids of the rotated keys still accepted to verify tokens",
+				Computed:            true,
+			},
+			"next_rotation": schema.StringAttribute{
+				MarkdownDescription: "Estimated time of the next key rotation in RFC 3339 format, empty when unknown",
+				Computed:            true,
+			},
+		}),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkFromDexDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkFromDexDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkFromDexDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tlsConfig *tls.Config
+	if caCertificate := data.CaCertificate.ValueString(); caCertificate != "" {
+		caCertPool := x509.NewCertPool()
+		if ok := caCertPool.AppendCertsFromPEM([]byte(caCertificate)); !ok {
+			resp.Diagnostics.AddAttributeError(path.Root("ca_certificate"), "AppendCertsFromPEM", "Can't load CA certificate")
+			return
+		}
+		tlsConfig = &tls.Config{RootCAs: caCertPool}
+	}
+	client, err := data.fetchClient(d.provider.newHTTPClient(tlsConfig))
+	if err != nil {
+		resp.Diagnostics.AddError("fetchClient", fmt.Sprintf("Can't configure HTTP client : %s", err))
+		return
+	}
+
+	issuer := data.Issuer.ValueString()
+	discovery, err := jwkutil.FetchOidcDiscovery(ctx, client, issuer)
+	if err != nil {
+		resp.Diagnostics.AddError("fetchOidcDiscovery", fmt.Sprintf("Fail to fetch OIDC discovery document : %s", err))
+		return
+	}
+
+	doc, diags := fetchJwksDocument(ctx, client, discovery.JwksUri, data.FetchOptionsModel)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Dex publishes the signing key first, followed by the verification
+	// keys, and caches the key set until the next rotation.
+	var kids []string
+	for _, key := range doc.Keys {
+		kid, err := jwkutil.Kid(key)
+		if err != nil {
+			resp.Diagnostics.AddError("Unmarshal", fmt.Sprintf("Can't unmarshal kid : %s", err))
+			return
+		}
+		kids = append(kids, kid)
+	}
+	signingKid := ""
+	verificationKids := []string{}
+	if len(kids) > 0 {
+		signingKid = kids[0]
+		verificationKids = kids[1:]
+	}
+
+	nextRotation := ""
+	if maxAge, ok := cacheControlMaxAge(doc.Header.Get("Cache-Control")); ok {
+		nextRotation = time.Now().Add(maxAge).UTC().Format(time.RFC3339)
+	}
+
+	doc.Keys, data.Jwks, data.Keys = d.provider.finishKeys(&resp.Diagnostics, path.Empty(), doc.Keys)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.VerificationKids, diags = types.ListValueFrom(ctx, types.StringType, verificationKids)
+	resp.Diagnostics.Append(diags...)
+	data.Id = types.StringValue(issuer)
+	data.JwksUri = types.StringValue(discovery.JwksUri)
+	data.NextRotation = types.StringValue(nextRotation)
+	data.SigningKid = types.StringValue(signingKid)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// cacheControlMaxAge returns the max-age directive of a Cache-Control header.
+func cacheControlMaxAge(cacheControl string) (time.Duration, bool) {
+	for _, directive := range strings.Split(cacheControl, ",") {
+		name, value, found := strings.Cut(strings.TrimSpace(directive), "=")
+		if !found || !strings.EqualFold(name, "max-age") {
+			continue
+		}
+		seconds, err := strconv.Atoi(strings.Trim(value, `"`))
+		if err != nil {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	return 0, false
+}
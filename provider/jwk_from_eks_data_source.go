@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &JwkFromEksDataSource{}
+
+type JwkFromEksDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkFromEksDataSourceModel struct {
+	AwsCredentialsModel
+	FetchOptionsModel
+	ClusterName types.String   `tfsdk:"cluster_name"`
+	Id          types.String   `tfsdk:"id"`
+	Issuer      types.String   `tfsdk:"issuer"`
+	Jwks        types.List     `tfsdk:"jwks"`
+	Keys        types.List     `tfsdk:"keys"`
+	JwksUri     types.String   `tfsdk:"jwks_uri"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+type EksDescribeClusterResp struct {
+	Cluster struct {
+		Arn      string `json:"arn"`
+		Identity struct {
+			Oidc struct {
+				Issuer string `json:"issuer"`
+			} `json:"oidc"`
+		} `json:"identity"`
+	} `json:"cluster"`
+}
+
+func NewJwkFromEksDataSource() datasource.DataSource {
+	return &JwkFromEksDataSource{}
+}
+
+func (d *JwkFromEksDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_from_eks"
+}
+
+func (d *JwkFromEksDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to fetch the JWKs of an EKS cluster OIDC issuer",
+
+		Attributes: withFetchOptionsAttributes(withAwsCredentialsAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"cluster_name": schema.StringAttribute{
+				MarkdownDescription: "EKS cluster name",
+				Required:            true,
+			},
+			"issuer": schema.StringAttribute{
+				MarkdownDescription: "OIDC issuer URL of the cluster",
+				Computed:            true,
+			},
+			"jwks_uri": schema.StringAttribute{
+				MarkdownDescription: "JWKS URI of the cluster",
+				Computed:            true,
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKs",
+				Computed:            true,
+			},
+			"keys": keysAttribute(false),
+		})),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkFromEksDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkFromEksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkFromEksDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	region, err := data.region()
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("region"), "region", fmt.Sprintf("Can't resolve AWS region : %s", err))
+		return
+	}
+
+	client := d.provider.newHTTPClient(nil)
+
+	creds, err := data.credentials(ctx, client)
+	if err != nil {
+		resp.Diagnostics.AddError("credentials", fmt.Sprintf("Can't resolve AWS credentials : %s", err))
+		return
+	}
+
+	clusterName := data.ClusterName.ValueString()
+	eksReq, err := http.NewRequest(http.MethodGet, fmt.Sprintf("https://eks.%s.amazonaws.com/clusters/%s", region, url.PathEscape(clusterName)), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("NewRequest", fmt.Sprintf("Can't create EKS request : %s", err))
+		return
+	}
+
+	eksData, err := awsRequest(ctx, client, creds, region, "eks", eksReq, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("DescribeCluster", fmt.Sprintf("Fail to describe EKS cluster %s : %s", clusterName, err))
+		return
+	}
+
+	var eksResp EksDescribeClusterResp
+	err = json.Unmarshal(eksData, &eksResp)
+	if err != nil {
+		resp.Diagnostics.AddError("Unmarshal", fmt.Sprintf("Can't unmarshal EksDescribeClusterResp : %s", err))
+		return
+	}
+
+	issuer := eksResp.Cluster.Identity.Oidc.Issuer
+	if issuer == "" {
+		resp.Diagnostics.AddError("DescribeCluster", fmt.Sprintf("EKS cluster %s has no OIDC issuer", clusterName))
+		return
+	}
+
+	discovery, keys, diags := fetchOidcJwks(ctx, client, issuer, data.FetchOptionsModel)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, data.Jwks, data.Keys = d.provider.finishKeys(&resp.Diagnostics, path.Empty(), keys)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(eksResp.Cluster.Arn)
+	data.Issuer = types.StringValue(issuer)
+	data.JwksUri = types.StringValue(discovery.JwksUri)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
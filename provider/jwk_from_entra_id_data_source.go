@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	entraIdDefaultAuthorityHost = "https://login.microsoftonline.com"
+	entraIdDefaultTenantId      = "common"
+	entraIdTenantIdPlaceholder  = "{tenantid}"
+)
+
+var _ datasource.DataSource = &JwkFromEntraIdDataSource{}
+
+type JwkFromEntraIdDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkFromEntraIdDataSourceModel struct {
+	FetchOptionsModel
+	AuthorityHost  types.String   `tfsdk:"authority_host"`
+	Id             types.String   `tfsdk:"id"`
+	Issuer         types.String   `tfsdk:"issuer"`
+	IssuerTemplate types.String   `tfsdk:"issuer_template"`
+	Jwks           types.List     `tfsdk:"jwks"`
+	Keys           types.List     `tfsdk:"keys"`
+	JwksUri        types.String   `tfsdk:"jwks_uri"`
+	TenantId       types.String   `tfsdk:"tenant_id"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+	V1             types.Bool     `tfsdk:"v1"`
+}
+
+func NewJwkFromEntraIdDataSource() datasource.DataSource {
+	return &JwkFromEntraIdDataSource{}
+}
+
+func (d *JwkFromEntraIdDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_from_entra_id"
+}
+
+func (d *JwkFromEntraIdDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to fetch the JWKs of an Azure AD / Entra ID tenant",
+
+		Attributes: withFetchOptionsAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"tenant_id": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Tenant ID or domain, or one of `common`, `organizations` and `consumers` (default: `%s`)", entraIdDefaultTenantId),
+				Optional:            true,
+			},
+			"authority_host": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Authority host, for national clouds (default: `%s`)", entraIdDefaultAuthorityHost),
+				Optional:            true,
+			},
+			"v1": schema.BoolAttribute{
+				MarkdownDescription: "Use the v1.0 endpoints instead of the v2.0 ones",
+				Optional:            true,
+			},
+			"issuer": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Issuer of the tenant. It contains the `%s` placeholder for multi-tenant endpoints", entraIdTenantIdPlaceholder),
+				Computed:            true,
+			},
+			"issuer_template": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Issuer with the tenant ID replaced by the `%s` placeholder", entraIdTenantIdPlaceholder),
+				Computed:            true,
+			},
+			"jwks_uri": schema.StringAttribute{
+				MarkdownDescription: "JWKS URI of the tenant",
+				Computed:            true,
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKs",
+				Computed:            true,
+			},
+			"keys": keysAttribute(false),
+		}),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkFromEntraIdDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkFromEntraIdDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkFromEntraIdDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	authorityHost := strings.TrimRight(data.AuthorityHost.ValueString(), "/")
+	if authorityHost == "" {
+		authorityHost = entraIdDefaultAuthorityHost
+	}
+	tenantId := data.TenantId.ValueString()
+	if tenantId == "" {
+		tenantId = entraIdDefaultTenantId
+	}
+
+	authority := authorityHost + "/" + url.PathEscape(tenantId)
+	if !data.V1.ValueBool() {
+		authority += "/v2.0"
+	}
+
+	discovery, keys, diags := fetchOidcJwks(ctx, d.provider.newHTTPClient(nil), authority, data.FetchOptionsModel)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, data.Jwks, data.Keys = d.provider.finishKeys(&resp.Diagnostics, path.Empty(), keys)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(authority)
+	data.Issuer = types.StringValue(discovery.Issuer)
+	data.IssuerTemplate = types.StringValue(entraIdIssuerTemplate(discovery.Issuer))
+	data.JwksUri = types.StringValue(discovery.JwksUri)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// entraIdIssuerTemplate replaces the tenant ID, the first path segment of
+// issuer, with the placeholder.
+func entraIdIssuerTemplate(issuer string) string {
+	base, path, found := strings.Cut(strings.TrimPrefix(issuer, "https://"), "/")
+	if !found {
+		return issuer
+	}
+	_, tail, _ := strings.Cut(path, "/")
+	if tail != "" || strings.HasSuffix(path, "/") {
+		tail = "/" + tail
+	}
+	return "https://" + base + "/" + entraIdTenantIdPlaceholder + tail
+}
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ datasource.DataSource = &JwkFromFileDataSource{}
+
+type JwkFromFileDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkFromFileDataSourceModel struct {
+	Base64Decode types.Bool     `tfsdk:"base64_decode"`
+	Id           types.String   `tfsdk:"id"`
+	Jwks         types.List     `tfsdk:"jwks"`
+	Keys         types.List     `tfsdk:"keys"`
+	Path         types.String   `tfsdk:"path"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewJwkFromFileDataSource() datasource.DataSource {
+	return &JwkFromFileDataSource{}
+}
+
+func (d *JwkFromFileDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_from_file"
+}
+
+func (d *JwkFromFileDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to read JWKs from a local file containing a JWK, a JWKS or PEM encoded keys",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Path of the file",
+				Required:            true,
+			},
+			"base64_decode": schema.BoolAttribute{
+				MarkdownDescription: "Decode the file content from base64 before parsing it",
+				Optional:            true,
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKs",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"keys": keysAttribute(true),
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkFromFileDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkFromFileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkFromFileDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filePath := data.Path.ValueString()
+	fileData, err := os.ReadFile(filePath)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "ReadFile", fmt.Sprintf("Can't read %s : %s", filePath, err))
+		return
+	}
+
+	if data.Base64Decode.ValueBool() {
+		fileData, err = base64.StdEncoding.DecodeString(strings.Join(strings.Fields(string(fileData)), ""))
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("base64_decode"), "DecodeString", fmt.Sprintf("Can't decode base64 content : %s", err))
+			return
+		}
+	}
+
+	jwks, err := jwkutil.ParseKeys(fileData)
+	if err != nil {
+		detail := fmt.Sprintf("Can't parse %s : %s", filePath, err)
+		if !data.Base64Decode.ValueBool() && looksLikeBase64Json(strings.TrimSpace(string(fileData))) {
+			detail += "\n\nHint: the file looks base64 encoded, set base64_decode"
+		}
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "ParseKeys", detail)
+		return
+	}
+
+	tflog.Debug(withRedaction(ctx), "Read JWKs from file", map[string]any{
+		"path":      filePath,
+		"key_count": len(jwks),
+		"kids":      jwkutil.Kids(jwks),
+	})
+
+	jwks, data.Jwks, data.Keys = d.provider.finishKeys(&resp.Diagnostics, path.Root("path"), jwks)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(filePath)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
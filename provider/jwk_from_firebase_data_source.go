@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ datasource.DataSource = &JwkFromFirebaseDataSource{}
+
+type JwkFromFirebaseDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkFromFirebaseDataSourceModel struct {
+	FetchOptionsModel
+	Audience  types.String   `tfsdk:"audience"`
+	Id        types.String   `tfsdk:"id"`
+	Issuer    types.String   `tfsdk:"issuer"`
+	Jwks      types.List     `tfsdk:"jwks"`
+	Keys      types.List     `tfsdk:"keys"`
+	JwksJson  types.String   `tfsdk:"jwks_json"`
+	ProjectId types.String   `tfsdk:"project_id"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewJwkFromFirebaseDataSource() datasource.DataSource {
+	return &JwkFromFirebaseDataSource{}
+}
+
+func (d *JwkFromFirebaseDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_from_firebase"
+}
+
+func (d *JwkFromFirebaseDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to fetch the keys signing the Firebase ID tokens of a project, converted from Google's securetoken x509 key map to JWKs",
+
+		Attributes: withFetchOptionsAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Firebase project ID",
+				Required:            true,
+			},
+			"issuer": schema.StringAttribute{
+				MarkdownDescription: "Issuer of the Firebase ID tokens",
+				Computed:            true,
+			},
+			"audience": schema.StringAttribute{
+				MarkdownDescription: "Audience of the Firebase ID tokens",
+				Computed:            true,
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKs",
+				Computed:            true,
+			},
+			"keys": keysAttribute(false),
+			"jwks_json": schema.StringAttribute{
+				MarkdownDescription: "JWKS document holding the keys",
+				Computed:            true,
+			},
+		}),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkFromFirebaseDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkFromFirebaseDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkFromFirebaseDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := data.fetchClient(d.provider.newHTTPClient(nil))
+	if err != nil {
+		resp.Diagnostics.AddError("fetchClient", fmt.Sprintf("Can't configure HTTP client : %s", err))
+		return
+	}
+
+	doc, diags := fetchJwksDocumentWith(ctx, firebaseX509Uri, data.FetchOptionsModel, func(ctx context.Context) (jwkutil.JwksDocument, error) {
+		certs, respData, err := getX509KeyMap(ctx, client, firebaseX509Uri)
+		if err != nil {
+			return jwkutil.JwksDocument{}, err
+		}
+		keys, err := jwkutil.X509KeyMapToJwks(certs, string(jose.RS256))
+		if err != nil {
+			return jwkutil.JwksDocument{}, err
+		}
+		return jwkutil.JwksDocument{Keys: keys, Raw: respData}, nil
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jwksJson, err := json.Marshal(JwksResp{Keys: doc.Keys})
+	if err != nil {
+		resp.Diagnostics.AddError("Marshal", fmt.Sprintf("Can't marshal JwksResp : %s", err))
+		return
+	}
+
+	doc.Keys, data.Jwks, data.Keys = d.provider.finishKeys(&resp.Diagnostics, path.Empty(), doc.Keys)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	projectId := data.ProjectId.ValueString()
+	data.Audience = types.StringValue(projectId)
+	data.Id = types.StringValue(projectId)
+	data.Issuer = types.StringValue(firebaseIssuerBase + projectId)
+	data.JwksJson = types.StringValue(string(jwksJson))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const githubActionsIssuer = "https://token.actions.githubusercontent.com"
+
+var _ datasource.DataSource = &JwkFromGithubActionsDataSource{}
+
+type JwkFromGithubActionsDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkFromGithubActionsDataSourceModel struct {
+	FetchOptionsModel
+	EnterpriseSlug types.String   `tfsdk:"enterprise_slug"`
+	Id             types.String   `tfsdk:"id"`
+	Issuer         types.String   `tfsdk:"issuer"`
+	Jwks           types.List     `tfsdk:"jwks"`
+	Keys           types.List     `tfsdk:"keys"`
+	JwksUri        types.String   `tfsdk:"jwks_uri"`
+	Thumbprints    types.List     `tfsdk:"thumbprints"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewJwkFromGithubActionsDataSource() datasource.DataSource {
+	return &JwkFromGithubActionsDataSource{}
+}
+
+func (d *JwkFromGithubActionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_from_github_actions"
+}
+
+func (d *JwkFromGithubActionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to fetch the issuer, JWKs and CA thumbprints of the GitHub Actions OIDC provider",
+
+		Attributes: withFetchOptionsAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"enterprise_slug": schema.StringAttribute{
+				MarkdownDescription: "Slug of the GitHub enterprise, when it uses a unique issuer URL",
+				Optional:            true,
+			},
+			"issuer": schema.StringAttribute{
+				MarkdownDescription: "Issuer of the GitHub Actions tokens",
+				Computed:            true,
+			},
+			"jwks_uri": schema.StringAttribute{
+				MarkdownDescription: "JWKS URI",
+				Computed:            true,
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKs",
+				Computed:            true,
+			},
+			"keys": keysAttribute(false),
+			"thumbprints": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "SHA-1 thumbprints of the CA certificates presented by the JWKS URI host, the top of the chain first",
+				Computed:            true,
+			},
+		}),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkFromGithubActionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkFromGithubActionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkFromGithubActionsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	issuer := githubActionsIssuer
+	if enterpriseSlug := data.EnterpriseSlug.ValueString(); enterpriseSlug != "" {
+		issuer += "/" + url.PathEscape(enterpriseSlug)
+	}
+
+	discovery, keys, diags := fetchOidcJwks(ctx, d.provider.newHTTPClient(nil), issuer, data.FetchOptionsModel)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	thumbprints, err := caThumbprints(ctx, discovery.JwksUri, d.provider)
+	if err != nil {
+		resp.Diagnostics.AddError("caThumbprints", fmt.Sprintf("Fail to compute CA thumbprints : %s", err))
+		return
+	}
+
+	keys, data.Jwks, data.Keys = d.provider.finishKeys(&resp.Diagnostics, path.Empty(), keys)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Thumbprints, diags = types.ListValueFrom(ctx, types.StringType, thumbprints)
+	resp.Diagnostics.Append(diags...)
+	data.Id = types.StringValue(issuer)
+	data.Issuer = types.StringValue(discovery.Issuer)
+	data.JwksUri = types.StringValue(discovery.JwksUri)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ datasource.DataSource = &JwkFromGkeDataSource{}
+
+type JwkFromGkeDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkFromGkeDataSourceModel struct {
+	FetchOptionsModel
+	GoogleCredentialsModel
+	ClusterName  types.String   `tfsdk:"cluster_name"`
+	Id           types.String   `tfsdk:"id"`
+	Issuer       types.String   `tfsdk:"issuer"`
+	Jwks         types.List     `tfsdk:"jwks"`
+	Keys         types.List     `tfsdk:"keys"`
+	JwksUri      types.String   `tfsdk:"jwks_uri"`
+	Location     types.String   `tfsdk:"location"`
+	Project      types.String   `tfsdk:"project"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+	WorkloadPool types.String   `tfsdk:"workload_pool"`
+}
+
+type GkeClusterResp struct {
+	WorkloadIdentityConfig struct {
+		WorkloadPool string `json:"workloadPool"`
+	} `json:"workloadIdentityConfig"`
+}
+
+func NewJwkFromGkeDataSource() datasource.DataSource {
+	return &JwkFromGkeDataSource{}
+}
+
+func (d *JwkFromGkeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_from_gke"
+}
+
+func (d *JwkFromGkeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to fetch the JWKs of a GKE cluster OIDC issuer",
+
+		Attributes: withFetchOptionsAttributes(withGoogleCredentialsAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Google Cloud project of the cluster",
+				Required:            true,
+			},
+			"location": schema.StringAttribute{
+				MarkdownDescription: "Region or zone of the cluster",
+				Required:            true,
+			},
+			"cluster_name": schema.StringAttribute{
+				MarkdownDescription: "GKE cluster name",
+				Required:            true,
+			},
+			"issuer": schema.StringAttribute{
+				MarkdownDescription: "OIDC issuer URL of the cluster",
+				Computed:            true,
+			},
+			"jwks_uri": schema.StringAttribute{
+				MarkdownDescription: "JWKS URI of the cluster",
+				Computed:            true,
+			},
+			"workload_pool": schema.StringAttribute{
+				MarkdownDescription: "Workload identity pool of the cluster, empty when workload identity is disabled",
+				Computed:            true,
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKs",
+				Computed:            true,
+			},
+			"keys": keysAttribute(false),
+		})),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkFromGkeDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkFromGkeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkFromGkeDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := d.provider.newHTTPClient(nil)
+
+	token, err := data.accessToken(ctx, client, googleCloudPlatformScope)
+	if err != nil {
+		resp.Diagnostics.AddError("accessToken", fmt.Sprintf("Can't get Google access token : %s", err))
+		return
+	}
+
+	issuer := fmt.Sprintf(
+		"https://container.googleapis.com/v1/projects/%s/locations/%s/clusters/%s",
+		url.PathEscape(data.Project.ValueString()),
+		url.PathEscape(data.Location.ValueString()),
+		url.PathEscape(data.ClusterName.ValueString()),
+	)
+
+	var gkeResp GkeClusterResp
+	err = jwkutil.GetJson(ctx, client, issuer, map[string]string{"Authorization": "Bearer " + token}, &gkeResp)
+	if err != nil {
+		resp.Diagnostics.AddError("GetCluster", fmt.Sprintf("Fail to get GKE cluster : %s", err))
+		return
+	}
+
+	discovery, keys, diags := fetchOidcJwks(ctx, client, issuer, data.FetchOptionsModel)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, data.Jwks, data.Keys = d.provider.finishKeys(&resp.Diagnostics, path.Empty(), keys)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(issuer)
+	data.Issuer = types.StringValue(discovery.Issuer)
+	data.JwksUri = types.StringValue(discovery.JwksUri)
+	data.WorkloadPool = types.StringValue(gkeResp.WorkloadIdentityConfig.WorkloadPool)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
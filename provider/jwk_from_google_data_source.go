@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &JwkFromGoogleDataSource{}
+
+type JwkFromGoogleDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkFromGoogleDataSourceModel struct {
+	FetchOptionsModel
+	Certificates types.Map      `tfsdk:"certificates"`
+	Id           types.String   `tfsdk:"id"`
+	Issuer       types.String   `tfsdk:"issuer"`
+	Jwks         types.List     `tfsdk:"jwks"`
+	Keys         types.List     `tfsdk:"keys"`
+	JwksUri      types.String   `tfsdk:"jwks_uri"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewJwkFromGoogleDataSource() datasource.DataSource {
+	return &JwkFromGoogleDataSource{}
+}
+
+func (d *JwkFromGoogleDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_from_google"
+}
+
+func (d *JwkFromGoogleDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to fetch the public keys Google uses to sign its ID tokens",
+
+		Attributes: withFetchOptionsAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"issuer": schema.StringAttribute{
+				MarkdownDescription: "Issuer of the Google ID tokens",
+				Computed:            true,
+			},
+			"jwks_uri": schema.StringAttribute{
+				MarkdownDescription: "JWKS URI",
+				Computed:            true,
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKs",
+				Computed:            true,
+			},
+			"keys": keysAttribute(false),
+			"certificates": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Map of key IDs to PEM certificates, as published in the legacy x509 format",
+				Computed:            true,
+			},
+		}),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkFromGoogleDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkFromGoogleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkFromGoogleDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := data.fetchClient(d.provider.newHTTPClient(nil))
+	if err != nil {
+		resp.Diagnostics.AddError("fetchClient", fmt.Sprintf("Can't configure HTTP client : %s", err))
+		return
+	}
+
+	keys, diags := fetchJwks(ctx, client, googleJwksUri, data.FetchOptionsModel)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	certs, _, err := getX509KeyMap(ctx, client, googleX509Uri)
+	if err != nil {
+		resp.Diagnostics.AddError("getX509KeyMap", fmt.Sprintf("Fail to fetch Google certificates : %s", err))
+		return
+	}
+	for kid, cert := range certs {
+		certs[kid] = strings.TrimSpace(cert)
+	}
+
+	keys, data.Jwks, data.Keys = d.provider.finishKeys(&resp.Diagnostics, path.Empty(), keys)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Certificates, diags = types.MapValueFrom(ctx, types.StringType, certs)
+	resp.Diagnostics.Append(diags...)
+	data.Id = types.StringValue(googleJwksUri)
+	data.Issuer = types.StringValue(googleIssuer)
+	data.JwksUri = types.StringValue(googleJwksUri)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -2,31 +2,29 @@ package provider
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"strings"
 
-	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ datasource.DataSource = &JwkFromK8sDataSource{}
 
-type JwkFromK8sDataSource struct{}
+type JwkFromK8sDataSource struct {
+	provider *JwkProviderData
+}
 
 type JwkFromK8sDataSourceModel struct {
-	ClientCertificate    types.String `tfsdk:"client_certificate"`
-	ClientKey            types.String `tfsdk:"client_key"`
-	ClusterCACertificate types.String `tfsdk:"cluster_ca_certificate"`
-	Host                 types.String `tfsdk:"host"`
-	Id                   types.String `tfsdk:"id"`
-	Jwks                 types.List   `tfsdk:"jwks"`
+	FetchOptionsModel
+	K8sAuthModel
+	Id       types.String   `tfsdk:"id"`
+	Jwks     types.List     `tfsdk:"jwks"`
+	Keys     types.List     `tfsdk:"keys"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 type JwksResp struct {
@@ -45,37 +43,27 @@ func (d *JwkFromK8sDataSource) Schema(ctx context.Context, req datasource.Schema
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "This data source can be used to fetcks JWKs from a K8S cluster",
 
-		Attributes: map[string]schema.Attribute{
+		Attributes: withFetchOptionsAttributes(withK8sAuthAttributes(map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				MarkdownDescription: "ID",
 				Computed:            true,
 			},
-			"client_certificate": schema.StringAttribute{
-				MarkdownDescription: "K8S Client Certificate",
-				Required:            true,
-			},
-			"client_key": schema.StringAttribute{
-				MarkdownDescription: "K8S Client Key",
-				Required:            true,
-			},
-			"cluster_ca_certificate": schema.StringAttribute{
-				MarkdownDescription: "K8S Cluster Certificate",
-				Required:            true,
-			},
-			"host": schema.StringAttribute{
-				MarkdownDescription: "K8S Host",
-				Required:            true,
-			},
 			"jwks": schema.ListAttribute{
 				ElementType:         types.StringType,
 				MarkdownDescription: "List of JWKs",
 				Computed:            true,
 			},
+			"keys": keysAttribute(false),
+		})),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
 		},
 	}
 }
 
 func (d *JwkFromK8sDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
 }
 
 func (d *JwkFromK8sDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -83,65 +71,43 @@ func (d *JwkFromK8sDataSource) Read(ctx context.Context, req datasource.ReadRequ
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	clientCertStr := data.ClientCertificate.ValueString()
-	clientKeyStr := data.ClientKey.ValueString()
-	cert, err := tls.X509KeyPair([]byte(clientCertStr), []byte(clientKeyStr))
+	conn, err := data.connection()
 	if err != nil {
-		resp.Diagnostics.AddError("X509KeyPair", fmt.Sprintf("Can't create X509: %s", err))
+		resp.Diagnostics.AddError("connection", fmt.Sprintf("Can't configure K8S connection : %s", err))
 		return
 	}
 
-	clusterCAStr := data.ClusterCACertificate.ValueString()
-	caCertPool := x509.NewCertPool()
-	if ok := caCertPool.AppendCertsFromPEM([]byte(clusterCAStr)); !ok {
-		resp.Diagnostics.AddError("AppendCertsFromPEM", "Can't load cluster CA")
-		return
-	}
-
-	tlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{cert},
-		RootCAs:      caCertPool,
-	}
-	transport := &http.Transport{TLSClientConfig: tlsConfig}
-	client := &http.Client{Transport: transport}
-
-	host := strings.TrimRight(data.Host.ValueString(), "/")
-	k8sResp, err := client.Get(host + "/openid/v1/jwks")
+	k8sClient, err := conn.httpClient(d.provider)
 	if err != nil {
-		resp.Diagnostics.AddError("Get", fmt.Sprintf("Fail to query K8S cluster : %s", err))
+		resp.Diagnostics.AddError("httpClient", fmt.Sprintf("Can't configure K8S TLS : %s", err))
 		return
 	}
-	defer k8sResp.Body.Close()
 
-	jwksData, err := io.ReadAll(k8sResp.Body)
+	client, err := data.fetchClient(k8sClient)
 	if err != nil {
-		resp.Diagnostics.AddError("ReadAll", fmt.Sprintf("Fail to read resp : %s", err))
+		resp.Diagnostics.AddError("fetchClient", fmt.Sprintf("Can't configure HTTP client : %s", err))
 		return
 	}
 
-	var jwksResp JwksResp
-	err = json.Unmarshal(jwksData, &jwksResp)
-	if err != nil {
-		resp.Diagnostics.AddError("Unmarshal", fmt.Sprintf("Can't unmarshal JwksResp : %s", err))
+	host := conn.host
+	keys, diags := fetchJwks(ctx, client, host+"/openid/v1/jwks", data.FetchOptionsModel)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var jwksAttr []attr.Value
-	for _, jwkRaw := range jwksResp.Keys {
-		jwk, err := json.Marshal(&jwkRaw)
-		if err != nil {
-			resp.Diagnostics.AddError("Marshal", fmt.Sprintf("Can't marshal jwkRaw : %s", err))
-			return
-		}
-		jwksAttr = append(jwksAttr, types.StringValue(string(jwk)))
+	keys, data.Jwks, data.Keys = d.provider.finishKeys(&resp.Diagnostics, path.Empty(), keys)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-
 	data.Id = types.StringValue(host)
-	data.Jwks, _ = types.ListValue(types.StringType, jwksAttr)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestJwkFromK8sDataSourceRedirect(t *testing.T) {
+	for _, name := range []string{"KUBE_HOST", "KUBE_TOKEN", "KUBE_CLUSTER_CA_CERT_DATA", "KUBE_CLIENT_CERT_DATA", "KUBE_CLIENT_KEY_DATA", "KUBE_CONFIG_PATH", "KUBECONFIG", "KUBE_CTX"} {
+		t.Setenv(name, "")
+	}
+
+	other := newRecordingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"keys":[{"kid":"k8s","kty":"oct","k":"AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8"}]}`)
+	})
+	apiServer := newRecordingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/openid/v1/jwks" {
+			http.NotFound(w, r)
+			return
+		}
+		http.Redirect(w, r, other.localhostUrl()+"/keys", http.StatusFound)
+	})
+
+	server, schemas := newTestProviderServer(t)
+	state, diags := readDataSource(t, server, schemas, "jwk_from_k8s", map[string]tftypes.Value{
+		"host":  tftypes.NewValue(tftypes.String, apiServer.URL),
+		"token": tftypes.NewValue(tftypes.String, "k8s-token"),
+	})
+	checkDiagnostics(t, diags)
+
+	if got := stringListValue(t, state["jwks"]); len(got) != 1 {
+		t.Errorf("jwks has %d keys, want 1", len(got))
+	}
+	if got := apiServer.received("Authorization"); len(got) != 1 || got[0] != "Bearer k8s-token" {
+		t.Errorf("API server received Authorization %v, want the token", got)
+	}
+	if other.requests() != 1 {
+		t.Errorf("redirect target served %d requests, want 1", other.requests())
+	}
+	if got := other.received("Authorization"); len(got) != 0 {
+		t.Errorf("redirect target received Authorization %v", got)
+	}
+}
@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ datasource.DataSource = &JwkFromK8sObjectDataSource{}
+
+type JwkFromK8sObjectDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkFromK8sObjectDataSourceModel struct {
+	FetchOptionsModel
+	K8sAuthModel
+	Id        types.String   `tfsdk:"id"`
+	Jwks      types.List     `tfsdk:"jwks"`
+	Keys      types.List     `tfsdk:"keys"`
+	Key       types.String   `tfsdk:"key"`
+	Kind      types.String   `tfsdk:"kind"`
+	Name      types.String   `tfsdk:"name"`
+	Namespace types.String   `tfsdk:"namespace"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+// K8sObject is the subset of a Secret or a ConfigMap holding its data.
+type K8sObject struct {
+	BinaryData map[string]string `json:"binaryData"`
+	Data       map[string]string `json:"data"`
+}
+
+func NewJwkFromK8sObjectDataSource() datasource.DataSource {
+	return &JwkFromK8sObjectDataSource{}
+}
+
+func (d *JwkFromK8sObjectDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_from_k8s_object"
+}
+
+func (d *JwkFromK8sObjectDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to read JWKs from a JWK, a JWKS or PEM encoded keys stored in a K8S Secret or ConfigMap",
+
+		Attributes: withFetchOptionsAttributes(withK8sAuthAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "Kind of the object, `Secret` or `ConfigMap` (default: `Secret`)",
+				Optional:            true,
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "Namespace of the object (default: `default`)",
+				Optional:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the object",
+				Required:            true,
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "Key of the object data holding the keys",
+				Required:            true,
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKs",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"keys": keysAttribute(true),
+		})),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkFromK8sObjectDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkFromK8sObjectDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkFromK8sObjectDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var resource string
+	switch kind := data.Kind.ValueString(); kind {
+	case "", "Secret":
+		resource = "secrets"
+	case "ConfigMap":
+		resource = "configmaps"
+	default:
+		resp.Diagnostics.AddAttributeError(path.Root("kind"), "kind", fmt.Sprintf("Unsupported kind %q", kind))
+		return
+	}
+
+	namespace := data.Namespace.ValueString()
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	conn, err := data.connection()
+	if err != nil {
+		resp.Diagnostics.AddError("connection", fmt.Sprintf("Can't configure K8S connection : %s", err))
+		return
+	}
+
+	k8sClient, err := conn.httpClient(d.provider)
+	if err != nil {
+		resp.Diagnostics.AddError("httpClient", fmt.Sprintf("Can't configure K8S TLS : %s", err))
+		return
+	}
+
+	client, err := data.fetchClient(k8sClient)
+	if err != nil {
+		resp.Diagnostics.AddError("fetchClient", fmt.Sprintf("Can't configure HTTP client : %s", err))
+		return
+	}
+
+	objectUrl := fmt.Sprintf("%s/api/v1/namespaces/%s/%s/%s", conn.host, url.PathEscape(namespace), resource, url.PathEscape(data.Name.ValueString()))
+	key := data.Key.ValueString()
+	doc, diags := fetchJwksDocumentWith(ctx, objectUrl, data.FetchOptionsModel, func(ctx context.Context) (jwkutil.JwksDocument, error) {
+		return getK8sObjectJwks(ctx, client, objectUrl, resource == "secrets", key)
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	doc.Keys, data.Jwks, data.Keys = d.provider.finishKeys(&resp.Diagnostics, path.Empty(), doc.Keys)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(objectUrl + "#" + key)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// getK8sObjectJwks gets the object at objectUrl and parses the keys stored in
+// its key entry.
+func getK8sObjectJwks(ctx context.Context, client *http.Client, objectUrl string, secret bool, key string) (jwkutil.JwksDocument, error) {
+	var object K8sObject
+	if err := jwkutil.GetJson(ctx, client, objectUrl, nil, &object); err != nil {
+		return jwkutil.JwksDocument{}, err
+	}
+
+	// Secret data and ConfigMap binary data are base64 encoded.
+	value, ok := object.Data[key]
+	encoded := secret
+	if !ok {
+		value, ok = object.BinaryData[key]
+		encoded = true
+	}
+	if !ok {
+		return jwkutil.JwksDocument{}, fmt.Errorf("no %q key in %s", key, objectUrl)
+	}
+
+	content := []byte(value)
+	if encoded {
+		var err error
+		content, err = base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return jwkutil.JwksDocument{}, fmt.Errorf("can't decode %q key: %s", key, err)
+		}
+	}
+
+	keys, err := jwkutil.ParseKeys(content)
+	if err != nil {
+		return jwkutil.JwksDocument{}, fmt.Errorf("can't parse %q key: %s", key, err)
+	}
+	return jwkutil.JwksDocument{Keys: keys, Raw: content}, nil
+}
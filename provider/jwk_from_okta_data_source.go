@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &JwkFromOktaDataSource{}
+
+type JwkFromOktaDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkFromOktaDataSourceModel struct {
+	FetchOptionsModel
+	ApiToken              types.String   `tfsdk:"api_token"`
+	AuthorizationServerId types.String   `tfsdk:"authorization_server_id"`
+	Id                    types.String   `tfsdk:"id"`
+	Issuer                types.String   `tfsdk:"issuer"`
+	Jwks                  types.List     `tfsdk:"jwks"`
+	Keys                  types.List     `tfsdk:"keys"`
+	JwksUri               types.String   `tfsdk:"jwks_uri"`
+	OrgUrl                types.String   `tfsdk:"org_url"`
+	Timeouts              timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewJwkFromOktaDataSource() datasource.DataSource {
+	return &JwkFromOktaDataSource{}
+}
+
+func (d *JwkFromOktaDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_from_okta"
+}
+
+func (d *JwkFromOktaDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to fetch the JWKs of an Okta org or authorization server",
+
+		Attributes: withFetchOptionsAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"org_url": schema.StringAttribute{
+				MarkdownDescription: "Okta org URL, e.g. `https://example.okta.com`",
+				Required:            true,
+			},
+			"authorization_server_id": schema.StringAttribute{
+				MarkdownDescription: "ID of a custom authorization server, e.g. `default`. The org authorization server is used when unset",
+				Optional:            true,
+			},
+			"api_token": schema.StringAttribute{
+				MarkdownDescription: "Okta API token sent with the requests to `org_url`, for private authorization servers",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"issuer": schema.StringAttribute{
+				MarkdownDescription: "Issuer of the authorization server",
+				Computed:            true,
+			},
+			"jwks_uri": schema.StringAttribute{
+				MarkdownDescription: "JWKS URI of the authorization server",
+				Computed:            true,
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKs",
+				Computed:            true,
+			},
+			"keys": keysAttribute(false),
+		}),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkFromOktaDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkFromOktaDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkFromOktaDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := d.provider.newHTTPClient(nil)
+	if apiToken := data.ApiToken.ValueString(); apiToken != "" {
+		client = withHeaders(client, data.OrgUrl.ValueString(), map[string]string{"Authorization": "SSWS " + apiToken})
+	}
+
+	issuer := strings.TrimRight(data.OrgUrl.ValueString(), "/")
+	if authorizationServerId := data.AuthorizationServerId.ValueString(); authorizationServerId != "" {
+		issuer += "/oauth2/" + url.PathEscape(authorizationServerId)
+	}
+
+	discovery, keys, diags := fetchOidcJwks(ctx, client, issuer, data.FetchOptionsModel)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, data.Jwks, data.Keys = d.provider.finishKeys(&resp.Diagnostics, path.Empty(), keys)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(issuer)
+	data.Issuer = types.StringValue(discovery.Issuer)
+	data.JwksUri = types.StringValue(discovery.JwksUri)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
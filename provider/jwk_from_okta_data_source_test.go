@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestJwkFromOktaDataSourceApiToken(t *testing.T) {
+	other := newRecordingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"keys":[{"kid":"okta","kty":"oct","k":"AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8"}]}`)
+	})
+
+	tests := []struct {
+		name    string
+		jwksUri func(okta *recordingServer) string
+	}{
+		{name: "jwks_uri on another host", jwksUri: func(okta *recordingServer) string { return other.localhostUrl() + "/keys" }},
+		{name: "redirect to another host", jwksUri: func(okta *recordingServer) string { return okta.URL + "/redirect" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var okta *recordingServer
+			okta = newRecordingServer(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/.well-known/openid-configuration":
+					io.WriteString(w, `{"issuer":"`+okta.URL+`","jwks_uri":"`+tt.jwksUri(okta)+`"}`)
+				case "/redirect":
+					http.Redirect(w, r, other.localhostUrl()+"/keys", http.StatusFound)
+				default:
+					http.NotFound(w, r)
+				}
+			})
+			requests := other.requests()
+
+			server, schemas := newTestProviderServer(t)
+			state, diags := readDataSource(t, server, schemas, "jwk_from_okta", map[string]tftypes.Value{
+				"api_token": tftypes.NewValue(tftypes.String, "okta-token"),
+				"org_url":   tftypes.NewValue(tftypes.String, okta.URL),
+			})
+			checkDiagnostics(t, diags)
+
+			if got := stringListValue(t, state["jwks"]); len(got) != 1 {
+				t.Errorf("jwks has %d keys, want 1", len(got))
+			}
+			if got := okta.received("Authorization"); len(got) == 0 || got[0] != "SSWS okta-token" {
+				t.Errorf("Okta received Authorization %v, want the API token", got)
+			}
+			if other.requests() != requests+1 {
+				t.Errorf("other host served %d requests, want 1", other.requests()-requests)
+			}
+			if got := other.received("Authorization"); len(got) != 0 {
+				t.Errorf("other host received Authorization %v", got)
+			}
+		})
+	}
+}
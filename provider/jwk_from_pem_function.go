@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ function.Function = &JwkFromPemFunction{}
+
+type JwkFromPemFunction struct{}
+
+func NewJwkFromPemFunction() function.Function {
+	return &JwkFromPemFunction{}
+}
+
+func (f *JwkFromPemFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "from_pem"
+}
+
+func (f *JwkFromPemFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Convert a PEM encoded key to a JWK",
+		MarkdownDescription: "Returns the JWK of the first block of a PEM encoded public key, private key or certificate, with the given `kid`, `use` and `alg` members. Null or empty members are left out",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "pem",
+				MarkdownDescription: "PEM encoded public key, private key or certificate",
+			},
+			function.StringParameter{
+				Name:                "kid",
+				MarkdownDescription: "Key ID",
+				AllowNullValue:      true,
+			},
+			function.StringParameter{
+				Name:                "use",
+				MarkdownDescription: "Public key use, `sig` or `enc`",
+				AllowNullValue:      true,
+			},
+			function.StringParameter{
+				Name:                "alg",
+				MarkdownDescription: "Algorithm of the key",
+				AllowNullValue:      true,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *JwkFromPemFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var pemStr string
+	var kid, use, alg types.String
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &pemStr, &kid, &use, &alg))
+	if resp.Error != nil {
+		return
+	}
+
+	block, _ := pem.Decode([]byte(strings.TrimSpace(pemStr)))
+	if block == nil {
+		resp.Error = function.NewArgumentFuncError(0, "No PEM block found")
+		return
+	}
+	jwk, err := jwkutil.PemBlockToJwk(block)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Can't convert PEM : %s", err))
+		return
+	}
+
+	switch use := use.ValueString(); use {
+	case "", "sig", "enc":
+		jwk.Use = use
+	default:
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("Unsupported use %q, expected sig or enc", use))
+		return
+	}
+	jwk.KeyID = kid.ValueString()
+	jwk.Algorithm = alg.ValueString()
+
+	jwkData, err := jwk.MarshalJSON()
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Can't marshal JWK : %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, string(jwkData)))
+}
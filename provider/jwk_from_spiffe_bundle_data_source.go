@@ -0,0 +1,306 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+const (
+	spiffeProfileHttpsWeb    = "https_web"
+	spiffeProfileHttpsSpiffe = "https_spiffe"
+)
+
+var _ datasource.DataSource = &JwkFromSpiffeBundleDataSource{}
+
+type JwkFromSpiffeBundleDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkFromSpiffeBundleDataSourceModel struct {
+	FetchOptionsModel
+	Bundle           types.String   `tfsdk:"bundle"`
+	EndpointSpiffeId types.String   `tfsdk:"endpoint_spiffe_id"`
+	EndpointUrl      types.String   `tfsdk:"endpoint_url"`
+	Id               types.String   `tfsdk:"id"`
+	Jwks             types.List     `tfsdk:"jwks"`
+	Keys             types.List     `tfsdk:"keys"`
+	Profile          types.String   `tfsdk:"profile"`
+	RefreshHint      types.Int64    `tfsdk:"refresh_hint"`
+	SequenceNumber   types.Int64    `tfsdk:"sequence_number"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+	TrustBundle      types.String   `tfsdk:"trust_bundle"`
+	X509Authorities  types.List     `tfsdk:"x509_authorities"`
+}
+
+type SpiffeBundle struct {
+	RefreshHint int64             `json:"spiffe_refresh_hint"`
+	Sequence    int64             `json:"spiffe_sequence"`
+	Keys        []json.RawMessage `json:"keys"`
+}
+
+type SpiffeBundleKey struct {
+	Use string   `json:"use"`
+	X5c []string `json:"x5c"`
+}
+
+func NewJwkFromSpiffeBundleDataSource() datasource.DataSource {
+	return &JwkFromSpiffeBundleDataSource{}
+}
+
+func (d *JwkFromSpiffeBundleDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_from_spiffe_bundle"
+}
+
+func (d *JwkFromSpiffeBundleDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to fetch the JWT authorities of a SPIFFE bundle endpoint",
+
+		Attributes: withFetchOptionsAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"endpoint_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the SPIFFE bundle endpoint",
+				Required:            true,
+			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Bundle endpoint profile, `%s` (default) or `%s`", spiffeProfileHttpsWeb, spiffeProfileHttpsSpiffe),
+				Optional:            true,
+			},
+			"endpoint_spiffe_id": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("SPIFFE ID of the bundle endpoint server, required by the `%s` profile", spiffeProfileHttpsSpiffe),
+				Optional:            true,
+			},
+			"trust_bundle": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("SPIFFE bundle or PEM certificates used to authenticate the bundle endpoint server, required by the `%s` profile", spiffeProfileHttpsSpiffe),
+				Optional:            true,
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWT authorities",
+				Computed:            true,
+			},
+			"keys": keysAttribute(false),
+			"x509_authorities": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of X.509 authorities in PEM format",
+				Computed:            true,
+			},
+			"sequence_number": schema.Int64Attribute{
+				MarkdownDescription: "Sequence number of the bundle",
+				Computed:            true,
+			},
+			"refresh_hint": schema.Int64Attribute{
+				MarkdownDescription: "Refresh hint of the bundle, in seconds",
+				Computed:            true,
+			},
+			"bundle": schema.StringAttribute{
+				MarkdownDescription: "Bundle as served by the endpoint",
+				Computed:            true,
+			},
+		}),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkFromSpiffeBundleDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkFromSpiffeBundleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkFromSpiffeBundleDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tlsConfig *tls.Config
+	switch profile := data.Profile.ValueString(); profile {
+	case "", spiffeProfileHttpsWeb:
+	case spiffeProfileHttpsSpiffe:
+		spiffeId := data.EndpointSpiffeId.ValueString()
+		if spiffeId == "" || data.TrustBundle.ValueString() == "" {
+			resp.Diagnostics.AddAttributeError(path.Root("profile"), "profile", fmt.Sprintf("endpoint_spiffe_id and trust_bundle are required by the %s profile", profile))
+			return
+		}
+
+		roots, err := spiffeTrustBundle([]byte(data.TrustBundle.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddError("spiffeTrustBundle", fmt.Sprintf("Can't load trust bundle : %s", err))
+			return
+		}
+		tlsConfig = spiffeTlsConfig(roots, spiffeId)
+	default:
+		resp.Diagnostics.AddAttributeError(path.Root("profile"), "profile", fmt.Sprintf("Unsupported profile %q", profile))
+		return
+	}
+
+	endpointUrl := data.EndpointUrl.ValueString()
+	client, err := data.fetchClient(d.provider.newHTTPClient(tlsConfig))
+	if err != nil {
+		resp.Diagnostics.AddError("fetchClient", fmt.Sprintf("Can't configure HTTP client : %s", err))
+		return
+	}
+
+	doc, diags := fetchJwksDocument(ctx, client, endpointUrl, data.FetchOptionsModel)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var bundle SpiffeBundle
+	if err := json.Unmarshal(doc.Raw, &bundle); err != nil {
+		resp.Diagnostics.AddError("Unmarshal", fmt.Sprintf("Can't unmarshal SpiffeBundle : %s", err))
+		return
+	}
+
+	var jwtAuthorities []json.RawMessage
+	var x509Authorities []attr.Value
+	for _, key := range doc.Keys {
+		var bundleKey SpiffeBundleKey
+		if err := json.Unmarshal(key, &bundleKey); err != nil {
+			resp.Diagnostics.AddError("Unmarshal", fmt.Sprintf("Can't unmarshal SpiffeBundleKey : %s", err))
+			return
+		}
+
+		switch bundleKey.Use {
+		case "jwt-svid":
+			jwtAuthorities = append(jwtAuthorities, key)
+		case "x509-svid":
+			if len(bundleKey.X5c) != 1 {
+				resp.Diagnostics.AddError("x509-svid", "X.509 authorities must hold exactly one certificate")
+				return
+			}
+			certData, err := base64.StdEncoding.DecodeString(bundleKey.X5c[0])
+			if err != nil {
+				resp.Diagnostics.AddError("DecodeString", fmt.Sprintf("Can't decode X.509 authority : %s", err))
+				return
+			}
+			certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certData})
+			x509Authorities = append(x509Authorities, types.StringValue(strings.TrimSpace(string(certPem))))
+		}
+	}
+
+	jwtAuthorities, data.Jwks, data.Keys = d.provider.finishKeys(&resp.Diagnostics, path.Empty(), jwtAuthorities)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.X509Authorities, _ = types.ListValue(types.StringType, x509Authorities)
+	data.Id = types.StringValue(endpointUrl)
+	data.SequenceNumber = types.Int64Value(bundle.Sequence)
+	data.RefreshHint = types.Int64Value(bundle.RefreshHint)
+	data.Bundle = types.StringValue(string(doc.Raw))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// spiffeTrustBundle loads the X.509 authorities of a SPIFFE bundle or of PEM
+// encoded certificates.
+func spiffeTrustBundle(data []byte) (*x509.CertPool, error) {
+	roots := x509.NewCertPool()
+	if jwkutil.IsPem(data) {
+		if ok := roots.AppendCertsFromPEM(data); !ok {
+			return nil, fmt.Errorf("no certificate found")
+		}
+		return roots, nil
+	}
+
+	var bundle SpiffeBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, err
+	}
+
+	found := false
+	for _, key := range bundle.Keys {
+		var bundleKey SpiffeBundleKey
+		if err := json.Unmarshal(key, &bundleKey); err != nil {
+			return nil, err
+		}
+		if bundleKey.Use != "x509-svid" {
+			continue
+		}
+		for _, x5c := range bundleKey.X5c {
+			certData, err := base64.StdEncoding.DecodeString(x5c)
+			if err != nil {
+				return nil, err
+			}
+			cert, err := x509.ParseCertificate(certData)
+			if err != nil {
+				return nil, err
+			}
+			roots.AddCert(cert)
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("no X.509 authority found")
+	}
+
+	return roots, nil
+}
+
+// spiffeTlsConfig authenticates the server with its X.509 SVID as defined by
+// the https_spiffe profile, instead of the web PKI.
+func spiffeTlsConfig(roots *x509.CertPool, spiffeId string) *tls.Config {
+	return &tls.Config{
+		// The server certificate is verified against the trust bundle and
+		// the SPIFFE ID by VerifyPeerCertificate, not against its hostname.
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			var certs []*x509.Certificate
+			for _, rawCert := range rawCerts {
+				cert, err := x509.ParseCertificate(rawCert)
+				if err != nil {
+					return err
+				}
+				certs = append(certs, cert)
+			}
+			if len(certs) == 0 {
+				return fmt.Errorf("no server certificate")
+			}
+
+			intermediates := x509.NewCertPool()
+			for _, cert := range certs[1:] {
+				intermediates.AddCert(cert)
+			}
+			_, err := certs[0].Verify(x509.VerifyOptions{
+				Roots:         roots,
+				Intermediates: intermediates,
+				KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, uri := range certs[0].URIs {
+				if uri.String() == spiffeId {
+					return nil
+				}
+			}
+			return fmt.Errorf("server certificate doesn't have SPIFFE ID %s", spiffeId)
+		},
+	}
+}
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ datasource.DataSource = &JwkFromVaultDataSource{}
+
+type JwkFromVaultDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkFromVaultDataSourceModel struct {
+	FetchOptionsModel
+	Address      types.String   `tfsdk:"address"`
+	Id           types.String   `tfsdk:"id"`
+	Issuer       types.String   `tfsdk:"issuer"`
+	Jwks         types.List     `tfsdk:"jwks"`
+	Keys         types.List     `tfsdk:"keys"`
+	JwksUri      types.String   `tfsdk:"jwks_uri"`
+	Namespace    types.String   `tfsdk:"namespace"`
+	OidcProvider types.String   `tfsdk:"oidc_provider"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+	Token        types.String   `tfsdk:"token"`
+}
+
+func NewJwkFromVaultDataSource() datasource.DataSource {
+	return &JwkFromVaultDataSource{}
+}
+
+func (d *JwkFromVaultDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_from_vault"
+}
+
+func (d *JwkFromVaultDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to fetch the JWKs published by the Vault identity secrets engine",
+
+		Attributes: withFetchOptionsAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"address": schema.StringAttribute{
+				MarkdownDescription: "Vault address, defaults to `VAULT_ADDR`",
+				Optional:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Vault token, defaults to `VAULT_TOKEN`",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "Vault namespace, defaults to `VAULT_NAMESPACE`",
+				Optional:            true,
+			},
+			"oidc_provider": schema.StringAttribute{
+				MarkdownDescription: "Name of the Vault OIDC provider. The keys of the identity tokens are fetched when unset",
+				Optional:            true,
+			},
+			"issuer": schema.StringAttribute{
+				MarkdownDescription: "Issuer of the tokens",
+				Computed:            true,
+			},
+			"jwks_uri": schema.StringAttribute{
+				MarkdownDescription: "JWKS URI",
+				Computed:            true,
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKs",
+				Computed:            true,
+			},
+			"keys": keysAttribute(false),
+		}),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkFromVaultDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkFromVaultDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkFromVaultDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	address, headers, err := vaultConfig(data.Address, data.Token, data.Namespace)
+	if err != nil {
+		resp.Diagnostics.AddError("vaultConfig", fmt.Sprintf("Can't configure Vault : %s", err))
+		return
+	}
+	client, err := data.fetchClient(withHeaders(d.provider.newHTTPClient(nil), address, headers))
+	if err != nil {
+		resp.Diagnostics.AddError("fetchClient", fmt.Sprintf("Can't configure HTTP client : %s", err))
+		return
+	}
+
+	base := address + "/v1/identity/oidc"
+	if oidcProvider := data.OidcProvider.ValueString(); oidcProvider != "" {
+		base += "/provider/" + url.PathEscape(oidcProvider)
+	}
+
+	discovery, err := jwkutil.FetchOidcDiscovery(ctx, client, base)
+	if err != nil {
+		resp.Diagnostics.AddError("fetchOidcDiscovery", fmt.Sprintf("Fail to fetch OIDC discovery document : %s", err))
+		return
+	}
+
+	// jwks_uri is built from the issuer configured in Vault, which may not
+	// be reachable from here, so the keys are fetched through address.
+	jwksUri := base + "/.well-known/keys"
+	keys, diags := fetchJwks(ctx, client, jwksUri, data.FetchOptionsModel)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, data.Jwks, data.Keys = d.provider.finishKeys(&resp.Diagnostics, path.Empty(), keys)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(jwksUri)
+	data.Issuer = types.StringValue(discovery.Issuer)
+	data.JwksUri = types.StringValue(discovery.JwksUri)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestJwkFromVaultDataSourceRedirect(t *testing.T) {
+	other := newRecordingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"keys":[{"kid":"vault","kty":"oct","k":"AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8"}]}`)
+	})
+	vault := newRecordingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/identity/oidc/.well-known/openid-configuration":
+			io.WriteString(w, `{"issuer":"https://vault.example.com/v1/identity/oidc","jwks_uri":"https://vault.example.com/v1/identity/oidc/.well-known/keys"}`)
+		case "/v1/identity/oidc/.well-known/keys":
+			http.Redirect(w, r, other.localhostUrl()+"/keys", http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	server, schemas := newTestProviderServer(t)
+	state, diags := readDataSource(t, server, schemas, "jwk_from_vault", map[string]tftypes.Value{
+		"address": tftypes.NewValue(tftypes.String, vault.URL),
+		"token":   tftypes.NewValue(tftypes.String, "vault-token"),
+	})
+	checkDiagnostics(t, diags)
+
+	if got := stringListValue(t, state["jwks"]); len(got) != 1 {
+		t.Errorf("jwks has %d keys, want 1", len(got))
+	}
+	if got := vault.received("X-Vault-Token"); len(got) != 2 {
+		t.Errorf("Vault received the token %d times, want 2", len(got))
+	}
+	if other.requests() != 1 {
+		t.Errorf("redirect target served %d requests, want 1", other.requests())
+	}
+	if got := other.received("X-Vault-Token"); len(got) != 0 {
+		t.Errorf("redirect target received the Vault token %v", got)
+	}
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ datasource.DataSource = &JwkJweDecryptDataSource{}
+
+type JwkJweDecryptDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkJweDecryptDataSourceModel struct {
+	ContentEncryptionAlgorithms types.List     `tfsdk:"content_encryption_algorithms"`
+	ContentType                 types.String   `tfsdk:"content_type"`
+	Id                          types.String   `tfsdk:"id"`
+	Jwe                         types.String   `tfsdk:"jwe"`
+	Jwk                         types.String   `tfsdk:"jwk"`
+	KeyEncryptionAlgorithms     types.List     `tfsdk:"key_encryption_algorithms"`
+	KeyId                       types.String   `tfsdk:"key_id"`
+	Plaintext                   types.String   `tfsdk:"plaintext"`
+	Timeouts                    timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewJwkJweDecryptDataSource() datasource.DataSource {
+	return &JwkJweDecryptDataSource{}
+}
+
+func (d *JwkJweDecryptDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_jwe_decrypt"
+}
+
+func (d *JwkJweDecryptDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to decrypt a compact JWE with a private JWK",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"jwe": schema.StringAttribute{
+				MarkdownDescription: "Compact serialized JWE",
+				Required:            true,
+			},
+			"jwk": schema.StringAttribute{
+				MarkdownDescription: "Private JWK of the recipient",
+				Required:            true,
+				Sensitive:           true,
+				Validators:          []validator.String{jwkValidator{private: true}},
+			},
+			"key_encryption_algorithms": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Accepted key management algorithms, the JWE is rejected before any decryption when encrypted with another one, defaults to the `key_encryption_algorithms` of the provider",
+				Optional:            true,
+			},
+			"content_encryption_algorithms": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Accepted content encryption algorithms, the JWE is rejected before any decryption when encrypted with another one, defaults to the `content_encryption_algorithms` of the provider",
+				Optional:            true,
+			},
+			"plaintext": schema.StringAttribute{
+				MarkdownDescription: "Decrypted plaintext",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"content_type": schema.StringAttribute{
+				MarkdownDescription: "`cty` header",
+				Computed:            true,
+			},
+			"key_id": schema.StringAttribute{
+				MarkdownDescription: "`kid` header",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkJweDecryptDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkJweDecryptDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkJweDecryptDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jwk, err := jwkutil.ParseJwk(data.Jwk.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "UnmarshalJSON", withJwkHint(fmt.Sprintf("Can't unmarshal JWK : %s", err), data.Jwk.ValueString(), false))
+		return
+	}
+
+	keyAlgorithms, contentEncryptions, err := d.provider.encryptionAlgorithms(data.KeyEncryptionAlgorithms, data.ContentEncryptionAlgorithms)
+	if err != nil {
+		resp.Diagnostics.AddError("encryptionAlgorithms", fmt.Sprintf("Invalid algorithms : %s", err))
+		return
+	}
+
+	compact := data.Jwe.ValueString()
+	jwe, err := jose.ParseEncrypted(compact, keyAlgorithms, contentEncryptions)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwe"), "ParseEncrypted", fmt.Sprintf("Can't parse JWE : %s", err))
+		return
+	}
+
+	plaintext, err := jwe.Decrypt(jwk)
+	if err != nil {
+		resp.Diagnostics.AddError("Decrypt", fmt.Sprintf("Fail to decrypt JWE : %s", err))
+		return
+	}
+	if keys, err := jwkutil.ParseJwks(plaintext); err == nil {
+		if err := d.provider.checkOutputKeys(keys); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("plaintext"), "checkOutputKeys", fmt.Sprintf("Plaintext rejected by the provider policy : %s", err))
+			return
+		}
+	}
+
+	contentType, _ := jwe.Header.ExtraHeaders[jose.HeaderContentType].(string)
+	data.ContentType = types.StringValue(contentType)
+	data.Id = types.StringValue(sha256Hex([]byte(compact)))
+	data.KeyId = types.StringValue(jwe.Header.KeyID)
+	data.Plaintext = types.StringValue(string(plaintext))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ datasource.DataSource = &JwkJweEncryptDataSource{}
+
+type JwkJweEncryptDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkJweEncryptDataSourceModel struct {
+	Algorithm   types.String   `tfsdk:"algorithm"`
+	ContentType types.String   `tfsdk:"content_type"`
+	Encryption  types.String   `tfsdk:"encryption"`
+	Id          types.String   `tfsdk:"id"`
+	Jwe         types.String   `tfsdk:"jwe"`
+	Jwk         types.String   `tfsdk:"jwk"`
+	Plaintext   types.String   `tfsdk:"plaintext"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewJwkJweEncryptDataSource() datasource.DataSource {
+	return &JwkJweEncryptDataSource{}
+}
+
+func (d *JwkJweEncryptDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_jwe_encrypt"
+}
+
+func (d *JwkJweEncryptDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to encrypt a plaintext to a recipient JWK as a compact JWE",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"jwk": schema.StringAttribute{
+				MarkdownDescription: "JWK of the recipient, only its public part is used",
+				Required:            true,
+				Sensitive:           true,
+				Validators:          []validator.String{jwkValidator{}},
+			},
+			"plaintext": schema.StringAttribute{
+				MarkdownDescription: "Plaintext to encrypt, possibly another JWK",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "Key management algorithm, defaults to the `alg` of the JWK or to the usual algorithm of its key type",
+				Optional:            true,
+			},
+			"encryption": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Content encryption algorithm (default: `%s`)", jose.A256GCM),
+				Optional:            true,
+			},
+			"content_type": schema.StringAttribute{
+				MarkdownDescription: "`cty` header, e.g. `jwk+json` when encrypting a JWK",
+				Optional:            true,
+			},
+			"jwe": schema.StringAttribute{
+				MarkdownDescription: "Compact serialized JWE",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkJweEncryptDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkJweEncryptDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkJweEncryptDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jwkStr, err := d.provider.base64urlJwk(data.Jwk.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "base64urlJwk", fmt.Sprintf("Can't apply base64url handling : %s", err))
+		return
+	}
+	if err := d.provider.checkConformanceJwk(jwkStr); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "checkConformanceJwk", fmt.Sprintf("JWK rejected by the strict conformance : %s", err))
+		return
+	}
+	jwk, err := jwkutil.ParseJwk(jwkStr)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "UnmarshalJSON", withJwkHint(fmt.Sprintf("Can't unmarshal JWK : %s", err), jwkStr, false))
+		return
+	}
+
+	alg, err := jwkutil.KeyAlgorithm(jwk, data.Algorithm.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("algorithm"), "KeyAlgorithm", fmt.Sprintf("Can't select key management algorithm : %s", err))
+		return
+	}
+
+	enc := jose.A256GCM
+	if encryption := data.Encryption.ValueString(); encryption != "" {
+		enc = jose.ContentEncryption(encryption)
+	}
+	if err := d.provider.checkEncryptionKey(jwk, alg, enc); err != nil {
+		resp.Diagnostics.AddError("checkEncryptionKey", fmt.Sprintf("Encryption rejected by the provider policy : %s", err))
+		return
+	}
+	d.provider.checkWeakKey(&resp.Diagnostics, path.Root("jwk"), jwk, string(alg))
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	encrypter, err := newJweEncrypter(jwk, alg, enc, data.ContentType.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("NewEncrypter", fmt.Sprintf("Can't create encrypter : %s", err))
+		return
+	}
+
+	jwe, err := encrypter.Encrypt([]byte(data.Plaintext.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Encrypt", fmt.Sprintf("Can't encrypt plaintext : %s", err))
+		return
+	}
+
+	compact, err := jwe.CompactSerialize()
+	if err != nil {
+		resp.Diagnostics.AddError("CompactSerialize", fmt.Sprintf("Can't serialize JWE : %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(sha256Hex([]byte(compact)))
+	data.Jwe = types.StringValue(compact)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ function.Function = &JwkJwksFilterFunction{}
+
+type JwkJwksFilterFunction struct{}
+
+func NewJwkJwksFilterFunction() function.Function {
+	return &JwkJwksFilterFunction{}
+}
+
+func (f *JwkJwksFilterFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "jwks_filter"
+}
+
+func (f *JwkJwksFilterFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Filter the keys of a JWKS",
+		MarkdownDescription: "Returns a JWKS holding the keys of `jwks` matching every filter set, null or empty filters matching every key",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "jwks",
+				MarkdownDescription: "JWKS or JWK",
+			},
+			function.StringParameter{
+				Name:                "kty",
+				MarkdownDescription: "Key type of the keys to keep",
+				AllowNullValue:      true,
+			},
+			function.StringParameter{
+				Name:                "use",
+				MarkdownDescription: "Public key use of the keys to keep",
+				AllowNullValue:      true,
+			},
+			function.StringParameter{
+				Name:                "alg",
+				MarkdownDescription: "Algorithm of the keys to keep",
+				AllowNullValue:      true,
+			},
+			function.StringParameter{
+				Name:                "kid",
+				MarkdownDescription: "Key ID of the keys to keep",
+				AllowNullValue:      true,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *JwkJwksFilterFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var jwksStr string
+	var kty, use, alg, kid types.String
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &jwksStr, &kty, &use, &alg, &kid))
+	if resp.Error != nil {
+		return
+	}
+
+	keys, err := jwkutil.ParseJwks([]byte(jwksStr))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, withJwkHint(fmt.Sprintf("Can't parse JWKS : %s", err), jwksStr, true))
+		return
+	}
+
+	filtered := []json.RawMessage{}
+	for i, key := range keys {
+		var members struct {
+			Alg string `json:"alg"`
+			Kid string `json:"kid"`
+			Kty string `json:"kty"`
+			Use string `json:"use"`
+		}
+		if err := json.Unmarshal(key, &members); err != nil {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Can't unmarshal key #%d : %s", i, err))
+			return
+		}
+
+		if matchesFilter(kty, members.Kty) && matchesFilter(use, members.Use) && matchesFilter(alg, members.Alg) && matchesFilter(kid, members.Kid) {
+			filtered = append(filtered, key)
+		}
+	}
+
+	jwksData, err := json.Marshal(JwksResp{Keys: filtered})
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Can't marshal JwksResp : %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, string(jwksData)))
+}
+
+// matchesFilter reports whether value matches filter, null and empty filters
+// matching every value.
+func matchesFilter(filter types.String, value string) bool {
+	return filter.ValueString() == "" || filter.ValueString() == value
+}
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &JwkJwsDetachedSignDataSource{}
+
+type JwkJwsDetachedSignDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkJwsDetachedSignDataSourceModel struct {
+	Algorithm        types.String   `tfsdk:"algorithm"`
+	Headers          types.Map      `tfsdk:"headers"`
+	Id               types.String   `tfsdk:"id"`
+	Jwk              types.String   `tfsdk:"jwk"`
+	Jws              types.String   `tfsdk:"jws"`
+	Payload          types.String   `tfsdk:"payload"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+	UnencodedPayload types.Bool     `tfsdk:"unencoded_payload"`
+}
+
+func NewJwkJwsDetachedSignDataSource() datasource.DataSource {
+	return &JwkJwsDetachedSignDataSource{}
+}
+
+func (d *JwkJwsDetachedSignDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_jws_detached_sign"
+}
+
+func (d *JwkJwsDetachedSignDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to sign a payload with a private JWK as a JWS with a detached payload",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"jwk": schema.StringAttribute{
+				MarkdownDescription: "Private JWK signing the payload",
+				Required:            true,
+				Sensitive:           true,
+				Validators:          []validator.String{jwkValidator{private: true}},
+			},
+			"payload": schema.StringAttribute{
+				MarkdownDescription: "Payload to sign",
+				Required:            true,
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "Signature algorithm, defaults to the `alg` of the JWK or to the usual algorithm of its key type",
+				Optional:            true,
+			},
+			"headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Extra protected headers",
+				Optional:            true,
+			},
+			"unencoded_payload": schema.BoolAttribute{
+				MarkdownDescription: "Sign the payload as is, without base64url encoding it, as described by RFC 7797",
+				Optional:            true,
+			},
+			"jws": schema.StringAttribute{
+				MarkdownDescription: "Compact serialized JWS, without its payload",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkJwsDetachedSignDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkJwsDetachedSignDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkJwsDetachedSignDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jwkStr, err := d.provider.base64urlJwk(data.Jwk.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "base64urlJwk", fmt.Sprintf("Can't apply base64url handling : %s", err))
+		return
+	}
+	if err := d.provider.checkConformanceJwk(jwkStr); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "checkConformanceJwk", fmt.Sprintf("JWK rejected by the strict conformance : %s", err))
+		return
+	}
+	key, err := signingKey(jwkStr, data.Algorithm.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "signingKey", withJwkHint(fmt.Sprintf("Can't load signing key : %s", err), jwkStr, false))
+		return
+	}
+	if err := d.provider.checkSigningKey(key); err != nil {
+		resp.Diagnostics.AddError("checkSigningKey", fmt.Sprintf("Signing key rejected by the provider policy : %s", err))
+		return
+	}
+	d.provider.checkWeakSigningKey(&resp.Diagnostics, path.Root("jwk"), key)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	headers := map[string]string{}
+	if !data.Headers.IsNull() {
+		resp.Diagnostics.Append(data.Headers.ElementsAs(ctx, &headers, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	opts := (&jose.SignerOptions{}).WithBase64(!data.UnencodedPayload.ValueBool())
+	signer, err := newJwsSigner(key, opts, headers)
+	if err != nil {
+		resp.Diagnostics.AddError("NewSigner", fmt.Sprintf("Can't create signer : %s", err))
+		return
+	}
+
+	jws, err := signer.Sign([]byte(data.Payload.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Sign", fmt.Sprintf("Can't sign payload : %s", err))
+		return
+	}
+
+	detached, err := jws.DetachedCompactSerialize()
+	if err != nil {
+		resp.Diagnostics.AddError("DetachedCompactSerialize", fmt.Sprintf("Can't serialize JWS : %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(sha256Hex([]byte(detached)))
+	data.Jws = types.StringValue(detached)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
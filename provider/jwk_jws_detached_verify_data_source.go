@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &JwkJwsDetachedVerifyDataSource{}
+
+type JwkJwsDetachedVerifyDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkJwsDetachedVerifyDataSourceModel struct {
+	Algorithms types.List     `tfsdk:"algorithms"`
+	Id         types.String   `tfsdk:"id"`
+	Jwk        types.String   `tfsdk:"jwk"`
+	Jwks       types.List     `tfsdk:"jwks"`
+	Jws        types.String   `tfsdk:"jws"`
+	KeyId      types.String   `tfsdk:"key_id"`
+	Payload    types.String   `tfsdk:"payload"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewJwkJwsDetachedVerifyDataSource() datasource.DataSource {
+	return &JwkJwsDetachedVerifyDataSource{}
+}
+
+func (d *JwkJwsDetachedVerifyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_jws_detached_verify"
+}
+
+func (d *JwkJwsDetachedVerifyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to verify a JWS with a detached payload against a JWK or a JWKS",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"jws": schema.StringAttribute{
+				MarkdownDescription: "Compact serialized JWS, without its payload",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"payload": schema.StringAttribute{
+				MarkdownDescription: "Detached payload",
+				Required:            true,
+			},
+			"jwk": schema.StringAttribute{
+				MarkdownDescription: "JWK or JWKS verifying the JWS, conflicts with `jwks`",
+				Optional:            true,
+				Sensitive:           true,
+				Validators:          []validator.String{jwkValidator{jwks: true}},
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKs verifying the JWS, conflicts with `jwk`",
+				Optional:            true,
+				Sensitive:           true,
+				Validators:          []validator.List{jwkListValidator{}},
+			},
+			"algorithms": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Accepted signature algorithms, the JWS is rejected before any verification when signed with another one, defaults to the `signature_algorithms` of the provider",
+				Optional:            true,
+			},
+			"key_id": schema.StringAttribute{
+				MarkdownDescription: "Key ID of the JWK that verified the JWS",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkJwsDetachedVerifyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkJwsDetachedVerifyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkJwsDetachedVerifyDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, err := keySet(data.Jwk, data.Jwks)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(keySetPath(data.Jwk), "keySet", withJwkHint(fmt.Sprintf("Can't read keys : %s", err), data.Jwk.ValueString(), true))
+		return
+	}
+	keys, err = d.provider.base64urlKeys(keys)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(keySetPath(data.Jwk), "base64urlKeys", fmt.Sprintf("Can't apply base64url handling : %s", err))
+		return
+	}
+	if err := d.provider.checkConformance(keys); err != nil {
+		resp.Diagnostics.AddAttributeError(keySetPath(data.Jwk), "checkConformance", fmt.Sprintf("Keys rejected by the strict conformance : %s", err))
+		return
+	}
+	d.provider.checkWeakKeys(&resp.Diagnostics, data.Jwk, keys)
+	d.provider.checkExpiringKeys(&resp.Diagnostics, data.Jwk, keys)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	algorithms, err := d.provider.signatureAlgorithms(data.Algorithms)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("algorithms"), "signatureAlgorithms", fmt.Sprintf("Invalid algorithms : %s", err))
+		return
+	}
+
+	detached := data.Jws.ValueString()
+	jws, err := jose.ParseSigned(detached, algorithms)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jws"), "ParseSigned", fmt.Sprintf("Can't parse JWS : %s", err))
+		return
+	}
+
+	_, kid, err := verifyJws(d.provider, jws, keys, []byte(data.Payload.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("verifyJws", fmt.Sprintf("Fail to verify JWS : %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(sha256Hex([]byte(detached)))
+	data.KeyId = types.StringValue(kid)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,220 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &JwkJwsSignDataSource{}
+
+type JwkJwsSignDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkJwsSignDataSourceModel struct {
+	Algorithm     types.String   `tfsdk:"algorithm"`
+	Headers       types.Map      `tfsdk:"headers"`
+	Id            types.String   `tfsdk:"id"`
+	Jwk           types.String   `tfsdk:"jwk"`
+	Jwks          types.List     `tfsdk:"jwks"`
+	Jws           types.String   `tfsdk:"jws"`
+	Payload       types.String   `tfsdk:"payload"`
+	PayloadBase64 types.String   `tfsdk:"payload_base64"`
+	Serialization types.String   `tfsdk:"serialization"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewJwkJwsSignDataSource() datasource.DataSource {
+	return &JwkJwsSignDataSource{}
+}
+
+func (d *JwkJwsSignDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_jws_sign"
+}
+
+func (d *JwkJwsSignDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to sign an arbitrary payload with one or more private JWKs as a JWS",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"jwk": schema.StringAttribute{
+				MarkdownDescription: "Private JWK or JWKS signing the payload, conflicts with `jwks`",
+				Optional:            true,
+				Sensitive:           true,
+				Validators:          []validator.String{jwkValidator{jwks: true, private: true}},
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of private JWKs signing the payload, conflicts with `jwk`",
+				Optional:            true,
+				Sensitive:           true,
+				Validators:          []validator.List{jwkListValidator{private: true}},
+			},
+			"payload": schema.StringAttribute{
+				MarkdownDescription: "Payload to sign, conflicts with `payload_base64`",
+				Optional:            true,
+			},
+			"payload_base64": schema.StringAttribute{
+				MarkdownDescription: "Base64 encoded payload to sign, for binary payloads, conflicts with `payload`",
+				Optional:            true,
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "Signature algorithm of every key, defaults to the `alg` of each JWK or to the usual algorithm of its key type",
+				Optional:            true,
+			},
+			"headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Extra protected headers of every signature",
+				Optional:            true,
+			},
+			"serialization": schema.StringAttribute{
+				MarkdownDescription: "`compact` or `json`, the JSON serialization is required to sign with several keys (default: `compact`)",
+				Optional:            true,
+			},
+			"jws": schema.StringAttribute{
+				MarkdownDescription: "Serialized JWS",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkJwsSignDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkJwsSignDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkJwsSignDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var payload []byte
+	switch {
+	case !data.Payload.IsNull() && !data.PayloadBase64.IsNull():
+		resp.Diagnostics.AddAttributeError(path.Root("payload"), "payload", "Only one of payload and payload_base64 can be set")
+		return
+	case !data.PayloadBase64.IsNull():
+		var err error
+		payload, err = base64.StdEncoding.DecodeString(data.PayloadBase64.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("payload_base64"), "DecodeString", fmt.Sprintf("Can't decode payload_base64 : %s", err))
+			return
+		}
+	case !data.Payload.IsNull():
+		payload = []byte(data.Payload.ValueString())
+	default:
+		resp.Diagnostics.AddAttributeError(path.Root("payload"), "payload", "One of payload and payload_base64 must be set")
+		return
+	}
+
+	serialization := data.Serialization.ValueString()
+	if serialization == "" {
+		serialization = "compact"
+	}
+	if serialization != "compact" && serialization != "json" {
+		resp.Diagnostics.AddAttributeError(path.Root("serialization"), "serialization", fmt.Sprintf("Unsupported serialization %q, expected compact or json", serialization))
+		return
+	}
+
+	jwks, err := keySet(data.Jwk, data.Jwks)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(keySetPath(data.Jwk), "keySet", withJwkHint(fmt.Sprintf("Can't read keys : %s", err), data.Jwk.ValueString(), true))
+		return
+	}
+	jwks, err = d.provider.base64urlKeys(jwks)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(keySetPath(data.Jwk), "base64urlKeys", fmt.Sprintf("Can't apply base64url handling : %s", err))
+		return
+	}
+	if err := d.provider.checkConformance(jwks); err != nil {
+		resp.Diagnostics.AddAttributeError(keySetPath(data.Jwk), "checkConformance", fmt.Sprintf("Keys rejected by the strict conformance : %s", err))
+		return
+	}
+	if len(jwks) > 1 && serialization == "compact" {
+		resp.Diagnostics.AddAttributeError(path.Root("serialization"), "serialization", "The compact serialization holds a single signature, use the json serialization to sign with several keys")
+		return
+	}
+
+	var keys []jose.SigningKey
+	for i, jwk := range jwks {
+		key, err := signingKey(string(jwk), data.Algorithm.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(keySetIndexPath(data.Jwk, i), "signingKey", withJwkHint(fmt.Sprintf("Can't load signing key : %s", err), string(jwk), false))
+			return
+		}
+		if err := d.provider.checkSigningKey(key); err != nil {
+			resp.Diagnostics.AddError("checkSigningKey", fmt.Sprintf("Signing key rejected by the provider policy : %s", err))
+			return
+		}
+		d.provider.checkWeakSigningKey(&resp.Diagnostics, keySetIndexPath(data.Jwk, i), key)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		keys = append(keys, key)
+	}
+
+	headers := map[string]string{}
+	if !data.Headers.IsNull() {
+		resp.Diagnostics.Append(data.Headers.ElementsAs(ctx, &headers, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	opts := &jose.SignerOptions{}
+	for name, value := range headers {
+		opts = opts.WithHeader(jose.HeaderKey(name), value)
+	}
+	signer, err := jose.NewMultiSigner(keys, opts)
+	if err != nil {
+		resp.Diagnostics.AddError("NewMultiSigner", fmt.Sprintf("Can't create signer : %s", err))
+		return
+	}
+
+	jws, err := signer.Sign(payload)
+	if err != nil {
+		resp.Diagnostics.AddError("Sign", fmt.Sprintf("Can't sign payload : %s", err))
+		return
+	}
+
+	var serialized string
+	if serialization == "json" {
+		serialized = jws.FullSerialize()
+	} else {
+		serialized, err = jws.CompactSerialize()
+		if err != nil {
+			resp.Diagnostics.AddError("CompactSerialize", fmt.Sprintf("Can't serialize JWS : %s", err))
+			return
+		}
+	}
+
+	data.Id = types.StringValue(sha256Hex([]byte(serialized)))
+	data.Jws = types.StringValue(serialized)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"unicode/utf8"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &JwkJwsVerifyDataSource{}
+
+type JwkJwsVerifyDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkJwsVerifyDataSourceModel struct {
+	Algorithms    types.List     `tfsdk:"algorithms"`
+	Id            types.String   `tfsdk:"id"`
+	Jwk           types.String   `tfsdk:"jwk"`
+	Jwks          types.List     `tfsdk:"jwks"`
+	Jws           types.String   `tfsdk:"jws"`
+	KeyId         types.String   `tfsdk:"key_id"`
+	Payload       types.String   `tfsdk:"payload"`
+	PayloadBase64 types.String   `tfsdk:"payload_base64"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewJwkJwsVerifyDataSource() datasource.DataSource {
+	return &JwkJwsVerifyDataSource{}
+}
+
+func (d *JwkJwsVerifyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_jws_verify"
+}
+
+func (d *JwkJwsVerifyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to verify a compact or JSON serialized JWS against a JWK or a JWKS and read its payload. A JWS with several signatures is valid as soon as one of them is",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"jws": schema.StringAttribute{
+				MarkdownDescription: "Compact or JSON serialized JWS",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"jwk": schema.StringAttribute{
+				MarkdownDescription: "JWK or JWKS verifying the JWS, conflicts with `jwks`",
+				Optional:            true,
+				Sensitive:           true,
+				Validators:          []validator.String{jwkValidator{jwks: true}},
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKs verifying the JWS, conflicts with `jwk`",
+				Optional:            true,
+				Sensitive:           true,
+				Validators:          []validator.List{jwkListValidator{}},
+			},
+			"algorithms": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Accepted signature algorithms, the JWS is rejected before any verification when signed with another one, defaults to the `signature_algorithms` of the provider",
+				Optional:            true,
+			},
+			"payload": schema.StringAttribute{
+				MarkdownDescription: "Verified payload, null when it isn't valid UTF-8",
+				Computed:            true,
+			},
+			"payload_base64": schema.StringAttribute{
+				MarkdownDescription: "Base64 encoded verified payload",
+				Computed:            true,
+			},
+			"key_id": schema.StringAttribute{
+				MarkdownDescription: "Key ID of the JWK that verified the JWS",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkJwsVerifyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkJwsVerifyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkJwsVerifyDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, err := keySet(data.Jwk, data.Jwks)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(keySetPath(data.Jwk), "keySet", withJwkHint(fmt.Sprintf("Can't read keys : %s", err), data.Jwk.ValueString(), true))
+		return
+	}
+	keys, err = d.provider.base64urlKeys(keys)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(keySetPath(data.Jwk), "base64urlKeys", fmt.Sprintf("Can't apply base64url handling : %s", err))
+		return
+	}
+	if err := d.provider.checkConformance(keys); err != nil {
+		resp.Diagnostics.AddAttributeError(keySetPath(data.Jwk), "checkConformance", fmt.Sprintf("Keys rejected by the strict conformance : %s", err))
+		return
+	}
+	d.provider.checkWeakKeys(&resp.Diagnostics, data.Jwk, keys)
+	d.provider.checkExpiringKeys(&resp.Diagnostics, data.Jwk, keys)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	algorithms, err := d.provider.signatureAlgorithms(data.Algorithms)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("algorithms"), "signatureAlgorithms", fmt.Sprintf("Invalid algorithms : %s", err))
+		return
+	}
+
+	serialized := data.Jws.ValueString()
+	jws, err := jose.ParseSigned(serialized, algorithms)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jws"), "ParseSigned", fmt.Sprintf("Can't parse JWS : %s", err))
+		return
+	}
+
+	payload, kid, err := verifyJws(d.provider, jws, keys, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("verifyJws", fmt.Sprintf("Fail to verify JWS : %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(sha256Hex([]byte(serialized)))
+	data.KeyId = types.StringValue(kid)
+	data.Payload = types.StringNull()
+	if utf8.Valid(payload) {
+		data.Payload = types.StringValue(string(payload))
+	}
+	data.PayloadBase64 = types.StringValue(base64.StdEncoding.EncodeToString(payload))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
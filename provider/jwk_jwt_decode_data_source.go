@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &JwkJwtDecodeDataSource{}
+
+type JwkJwtDecodeDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkJwtDecodeDataSourceModel struct {
+	Algorithm types.String   `tfsdk:"algorithm"`
+	Claims    types.String   `tfsdk:"claims"`
+	ExpiresAt types.String   `tfsdk:"expires_at"`
+	Header    types.String   `tfsdk:"header"`
+	Id        types.String   `tfsdk:"id"`
+	Issuer    types.String   `tfsdk:"issuer"`
+	KeyId     types.String   `tfsdk:"key_id"`
+	Subject   types.String   `tfsdk:"subject"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+	Token     types.String   `tfsdk:"token"`
+}
+
+// JwtHeader is the subset of a JOSE header exposed by the provider.
+type JwtHeader struct {
+	Algorithm string `json:"alg"`
+	KeyId     string `json:"kid"`
+}
+
+func NewJwkJwtDecodeDataSource() datasource.DataSource {
+	return &JwkJwtDecodeDataSource{}
+}
+
+func (d *JwkJwtDecodeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_jwt_decode"
+}
+
+func (d *JwkJwtDecodeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to decode the header and claims of a JWT **without verifying its signature**. The decoded values must not be trusted, use `jwk_jwt_verify` to verify the token",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Compact serialized JWT",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"header": schema.StringAttribute{
+				MarkdownDescription: "JSON encoded header, unverified",
+				Computed:            true,
+			},
+			"claims": schema.StringAttribute{
+				MarkdownDescription: "JSON encoded claims, unverified",
+				Computed:            true,
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "`alg` header, unverified",
+				Computed:            true,
+			},
+			"key_id": schema.StringAttribute{
+				MarkdownDescription: "`kid` header, unverified",
+				Computed:            true,
+			},
+			"issuer": schema.StringAttribute{
+				MarkdownDescription: "`iss` claim, unverified",
+				Computed:            true,
+			},
+			"subject": schema.StringAttribute{
+				MarkdownDescription: "`sub` claim, unverified",
+				Computed:            true,
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "`exp` claim in RFC 3339 format, unverified",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkJwtDecodeDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkJwtDecodeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkJwtDecodeDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token := data.Token.ValueString()
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		resp.Diagnostics.AddAttributeError(path.Root("token"), "token", fmt.Sprintf("Token has %d parts, expected 3", len(parts)))
+		return
+	}
+
+	headerData, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("token"), "DecodeString", fmt.Sprintf("Can't decode header : %s", err))
+		return
+	}
+	var header JwtHeader
+	if err := json.Unmarshal(headerData, &header); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("token"), "Unmarshal", fmt.Sprintf("Can't unmarshal header : %s", err))
+		return
+	}
+	if reason := weakKeyReason(jose.JSONWebKey{}, header.Algorithm); reason != "" {
+		d.provider.addWeakDiagnostic(&resp.Diagnostics, path.Root("token"), fmt.Sprintf("Token : %s", reason))
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	claimsData, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("token"), "DecodeString", fmt.Sprintf("Can't decode claims : %s", err))
+		return
+	}
+	var claims jwt.Claims
+	if err := json.Unmarshal(claimsData, &claims); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("token"), "Unmarshal", fmt.Sprintf("Can't unmarshal claims : %s", err))
+		return
+	}
+
+	data.Algorithm = types.StringValue(header.Algorithm)
+	data.Claims = types.StringValue(string(claimsData))
+	data.ExpiresAt = types.StringNull()
+	if claims.Expiry != nil {
+		data.ExpiresAt = types.StringValue(claims.Expiry.Time().UTC().Format(time.RFC3339))
+	}
+	data.Header = types.StringValue(string(headerData))
+	data.Id = types.StringValue(sha256Hex([]byte(token)))
+	data.Issuer = types.StringValue(claims.Issuer)
+	data.KeyId = types.StringValue(header.KeyId)
+	data.Subject = types.StringValue(claims.Subject)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// awsKmsSigningAlgorithms maps the JWS algorithms to the AWS KMS signing
+// algorithms.
+var awsKmsSigningAlgorithms = map[jose.SignatureAlgorithm]string{
+	jose.RS256: "RSASSA_PKCS1_V1_5_SHA_256",
+	jose.RS384: "RSASSA_PKCS1_V1_5_SHA_384",
+	jose.RS512: "RSASSA_PKCS1_V1_5_SHA_512",
+	jose.PS256: "RSASSA_PSS_SHA_256",
+	jose.PS384: "RSASSA_PSS_SHA_384",
+	jose.PS512: "RSASSA_PSS_SHA_512",
+	jose.ES256: "ECDSA_SHA_256",
+	jose.ES384: "ECDSA_SHA_384",
+	jose.ES512: "ECDSA_SHA_512",
+}
+
+var _ datasource.DataSource = &JwkJwtSignAwsKmsDataSource{}
+
+type JwkJwtSignAwsKmsDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkJwtSignAwsKmsDataSourceModel struct {
+	AwsCredentialsModel
+	JweEncryptionModel
+	JwtClaimsModel
+	Algorithm types.String   `tfsdk:"algorithm"`
+	Headers   types.Map      `tfsdk:"headers"`
+	Id        types.String   `tfsdk:"id"`
+	Jwk       types.String   `tfsdk:"jwk"`
+	KeyId     types.String   `tfsdk:"key_id"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+	Token     types.String   `tfsdk:"token"`
+}
+
+type AwsKmsGetPublicKeyResp struct {
+	KeyId             string   `json:"KeyId"`
+	PublicKey         []byte   `json:"PublicKey"`
+	SigningAlgorithms []string `json:"SigningAlgorithms"`
+}
+
+type AwsKmsSignResp struct {
+	Signature []byte `json:"Signature"`
+}
+
+func NewJwkJwtSignAwsKmsDataSource() datasource.DataSource {
+	return &JwkJwtSignAwsKmsDataSource{}
+}
+
+func (d *JwkJwtSignAwsKmsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_jwt_sign_aws_kms"
+}
+
+func (d *JwkJwtSignAwsKmsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to sign a JWT with an AWS KMS asymmetric key, the private key never leaves KMS. The token is signed again, with new time claims, on every read",
+
+		Attributes: withAwsCredentialsAttributes(withJweEncryptionAttributes(withJwtClaimsAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"key_id": schema.StringAttribute{
+				MarkdownDescription: "ID, ARN or alias of the KMS key",
+				Required:            true,
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "Signature algorithm, defaults to the usual algorithm of the key type",
+				Optional:            true,
+			},
+			"headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Extra protected headers",
+				Optional:            true,
+			},
+			"jwk": schema.StringAttribute{
+				MarkdownDescription: "Public JWK of the KMS key, its kid is the RFC 7638 thumbprint of the key",
+				Computed:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Compact serialized JWT, a JWE when `encryption_jwk` is set",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		}))),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkJwtSignAwsKmsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkJwtSignAwsKmsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkJwtSignAwsKmsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	region, err := data.region()
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("region"), "region", fmt.Sprintf("Can't resolve AWS region : %s", err))
+		return
+	}
+
+	client := d.provider.newHTTPClient(nil)
+
+	creds, err := data.credentials(ctx, client)
+	if err != nil {
+		resp.Diagnostics.AddError("credentials", fmt.Sprintf("Can't resolve AWS credentials : %s", err))
+		return
+	}
+
+	keyId := data.KeyId.ValueString()
+	var publicKeyResp AwsKmsGetPublicKeyResp
+	err = awsJsonRequest(ctx, client, creds, region, "kms", "TrentService.GetPublicKey", map[string]string{"KeyId": keyId}, &publicKeyResp)
+	if err != nil {
+		resp.Diagnostics.AddError("GetPublicKey", fmt.Sprintf("Fail to get public key of %s : %s", keyId, err))
+		return
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(publicKeyResp.PublicKey)
+	if err != nil {
+		resp.Diagnostics.AddError("ParsePKIXPublicKey", fmt.Sprintf("Can't parse public key of %s : %s", keyId, err))
+		return
+	}
+
+	var algs []jose.SignatureAlgorithm
+	for alg, kmsAlg := range awsKmsSigningAlgorithms {
+		for _, supported := range publicKeyResp.SigningAlgorithms {
+			if supported == kmsAlg {
+				algs = append(algs, alg)
+			}
+		}
+	}
+
+	signer, err := newRemoteSigner(publicKey, algs, true, func(alg jose.SignatureAlgorithm, digest []byte) ([]byte, error) {
+		var signResp AwsKmsSignResp
+		err := awsJsonRequest(ctx, client, creds, region, "kms", "TrentService.Sign", map[string]any{
+			"KeyId":            publicKeyResp.KeyId,
+			"Message":          digest,
+			"MessageType":      "DIGEST",
+			"SigningAlgorithm": awsKmsSigningAlgorithms[alg],
+		}, &signResp)
+		return signResp.Signature, err
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("newRemoteSigner", fmt.Sprintf("Can't create signer : %s", err))
+		return
+	}
+
+	token, jwk, diags := signer.signJwt(ctx, d.provider, data.Algorithm, data.Headers, data.JwtClaimsModel, data.JweEncryptionModel)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(sha256Hex([]byte(token)))
+	data.Jwk = types.StringValue(jwk)
+	data.Token = types.StringValue(token)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ datasource.DataSource = &JwkJwtSignAzureKeyVaultDataSource{}
+
+type JwkJwtSignAzureKeyVaultDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkJwtSignAzureKeyVaultDataSourceModel struct {
+	AzureCredentialsModel
+	JweEncryptionModel
+	JwtClaimsModel
+	Algorithm types.String   `tfsdk:"algorithm"`
+	Headers   types.Map      `tfsdk:"headers"`
+	Id        types.String   `tfsdk:"id"`
+	Jwk       types.String   `tfsdk:"jwk"`
+	KeyId     types.String   `tfsdk:"key_id"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+	Token     types.String   `tfsdk:"token"`
+}
+
+type AzureKeyVaultKeyResp struct {
+	Key map[string]any `json:"key"`
+}
+
+type AzureKeyVaultSignResp struct {
+	Value string `json:"value"`
+}
+
+func NewJwkJwtSignAzureKeyVaultDataSource() datasource.DataSource {
+	return &JwkJwtSignAzureKeyVaultDataSource{}
+}
+
+func (d *JwkJwtSignAzureKeyVaultDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_jwt_sign_azure_key_vault"
+}
+
+func (d *JwkJwtSignAzureKeyVaultDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to sign a JWT with an Azure Key Vault RSA or EC key, the private key never leaves Key Vault. The token is signed again, with new time claims, on every read",
+
+		Attributes: withAzureCredentialsAttributes(withJweEncryptionAttributes(withJwtClaimsAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"key_id": schema.StringAttribute{
+				MarkdownDescription: "Key identifier, `https://<vault>.vault.azure.net/keys/<name>` or `https://<vault>.vault.azure.net/keys/<name>/<version>`. The latest version is used when unset",
+				Required:            true,
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "Signature algorithm, defaults to the usual algorithm of the key type",
+				Optional:            true,
+			},
+			"headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Extra protected headers",
+				Optional:            true,
+			},
+			"jwk": schema.StringAttribute{
+				MarkdownDescription: "Public JWK of the Key Vault key, its kid is the RFC 7638 thumbprint of the key",
+				Computed:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Compact serialized JWT, a JWE when `encryption_jwk` is set",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		}))),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkJwtSignAzureKeyVaultDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkJwtSignAzureKeyVaultDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkJwtSignAzureKeyVaultDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := d.provider.newHTTPClient(nil)
+
+	accessToken, err := data.accessToken(ctx, client, azureKeyVaultResource)
+	if err != nil {
+		resp.Diagnostics.AddError("accessToken", fmt.Sprintf("Can't get Azure access token : %s", err))
+		return
+	}
+	headers := map[string]string{"Authorization": "Bearer " + accessToken}
+
+	keyId := strings.TrimSuffix(data.KeyId.ValueString(), "/")
+	var keyResp AzureKeyVaultKeyResp
+	err = jwkutil.GetJson(ctx, client, keyId+"?api-version="+azureKeyVaultApiVersion, headers, &keyResp)
+	if err != nil {
+		resp.Diagnostics.AddError("GetKey", fmt.Sprintf("Fail to get key %s : %s", keyId, err))
+		return
+	}
+
+	publicKey, err := azureKeyVaultPublicKey(keyResp.Key)
+	if err != nil {
+		resp.Diagnostics.AddError("azureKeyVaultPublicKey", fmt.Sprintf("Can't parse key %s : %s", keyId, err))
+		return
+	}
+
+	// Pin the version returned by Key Vault, so that every signature is
+	// made with the key matching the published JWK.
+	if kid, ok := keyResp.Key["kid"].(string); ok && kid != "" {
+		keyId = kid
+	}
+
+	var algs []jose.SignatureAlgorithm
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		algs = []jose.SignatureAlgorithm{jose.RS256, jose.RS384, jose.RS512, jose.PS256, jose.PS384, jose.PS512}
+	case *ecdsa.PublicKey:
+		alg, err := jwkutil.EcdsaAlgorithm(key.Curve.Params().BitSize)
+		if err != nil {
+			resp.Diagnostics.AddError("EcdsaAlgorithm", fmt.Sprintf("Can't sign with %s : %s", keyId, err))
+			return
+		}
+		algs = []jose.SignatureAlgorithm{alg}
+	}
+
+	signer, err := newRemoteSigner(publicKey, algs, false, func(alg jose.SignatureAlgorithm, digest []byte) ([]byte, error) {
+		var signResp AzureKeyVaultSignResp
+		err := postJson(ctx, client, keyId+"/sign?api-version="+azureKeyVaultApiVersion, headers, map[string]string{
+			"alg":   string(alg),
+			"value": base64.RawURLEncoding.EncodeToString(digest),
+		}, &signResp)
+		if err != nil {
+			return nil, err
+		}
+		return base64.RawURLEncoding.DecodeString(signResp.Value)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("newRemoteSigner", fmt.Sprintf("Can't create signer : %s", err))
+		return
+	}
+
+	token, jwk, diags := signer.signJwt(ctx, d.provider, data.Algorithm, data.Headers, data.JwtClaimsModel, data.JweEncryptionModel)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(sha256Hex([]byte(token)))
+	data.Jwk = types.StringValue(jwk)
+	data.Token = types.StringValue(token)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// azureKeyVaultPublicKey returns the public key of a Key Vault JWK, whose
+// kty may carry the -HSM suffix of HSM backed keys.
+func azureKeyVaultPublicKey(key map[string]any) (any, error) {
+	kty, _ := key["kty"].(string)
+	switch kty = strings.TrimSuffix(kty, "-HSM"); kty {
+	case "RSA", "EC":
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", key["kty"])
+	}
+
+	public := map[string]any{"kty": kty}
+	for _, name := range []string{"n", "e", "crv", "x", "y"} {
+		if value, ok := key[name]; ok {
+			public[name] = value
+		}
+	}
+	publicData, err := json.Marshal(public)
+	if err != nil {
+		return nil, err
+	}
+
+	var jwk jose.JSONWebKey
+	if err := jwk.UnmarshalJSON(publicData); err != nil {
+		return nil, err
+	}
+	return jwk.Key, nil
+}
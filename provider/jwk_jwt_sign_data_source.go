@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &JwkJwtSignDataSource{}
+
+type JwkJwtSignDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkJwtSignDataSourceModel struct {
+	JweEncryptionModel
+	JwtClaimsModel
+	Algorithm types.String   `tfsdk:"algorithm"`
+	Headers   types.Map      `tfsdk:"headers"`
+	Id        types.String   `tfsdk:"id"`
+	Jwk       types.String   `tfsdk:"jwk"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+	Token     types.String   `tfsdk:"token"`
+}
+
+func NewJwkJwtSignDataSource() datasource.DataSource {
+	return &JwkJwtSignDataSource{}
+}
+
+func (d *JwkJwtSignDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_jwt_sign"
+}
+
+func (d *JwkJwtSignDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to sign a JWT with a private JWK. The token is signed again, with new time claims, on every read",
+
+		Attributes: withJweEncryptionAttributes(withJwtClaimsAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"jwk": schema.StringAttribute{
+				MarkdownDescription: "Private JWK signing the token",
+				Required:            true,
+				Sensitive:           true,
+				Validators:          []validator.String{jwkValidator{private: true}},
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "Signature algorithm, defaults to the `alg` of the JWK or to the usual algorithm of its key type",
+				Optional:            true,
+			},
+			"headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Extra protected headers",
+				Optional:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Compact serialized JWT, a JWE when `encryption_jwk` is set",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		})),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkJwtSignDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkJwtSignDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkJwtSignDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jwkStr, err := d.provider.base64urlJwk(data.Jwk.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "base64urlJwk", fmt.Sprintf("Can't apply base64url handling : %s", err))
+		return
+	}
+	if err := d.provider.checkConformanceJwk(jwkStr); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "checkConformanceJwk", fmt.Sprintf("JWK rejected by the strict conformance : %s", err))
+		return
+	}
+	key, err := signingKey(jwkStr, data.Algorithm.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "signingKey", withJwkHint(fmt.Sprintf("Can't load signing key : %s", err), jwkStr, false))
+		return
+	}
+	if err := d.provider.checkSigningKey(key); err != nil {
+		resp.Diagnostics.AddError("checkSigningKey", fmt.Sprintf("Signing key rejected by the provider policy : %s", err))
+		return
+	}
+	d.provider.checkWeakSigningKey(&resp.Diagnostics, path.Root("jwk"), key)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	headers := map[string]string{}
+	if !data.Headers.IsNull() {
+		resp.Diagnostics.Append(data.Headers.ElementsAs(ctx, &headers, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	claims, err := data.claims(time.Now())
+	if err != nil {
+		resp.Diagnostics.AddError("claims", fmt.Sprintf("Can't build claims : %s", err))
+		return
+	}
+
+	signer, err := newJwtSigner(key, headers)
+	if err != nil {
+		resp.Diagnostics.AddError("NewSigner", fmt.Sprintf("Can't create signer : %s", err))
+		return
+	}
+
+	token, err := signJwt(signer, claims)
+	if err != nil {
+		resp.Diagnostics.AddError("signJwt", fmt.Sprintf("Can't sign token : %s", err))
+		return
+	}
+
+	token, err = data.encrypt(d.provider, token)
+	if err != nil {
+		resp.Diagnostics.AddError("encrypt", fmt.Sprintf("Can't encrypt token : %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(sha256Hex([]byte(token)))
+	data.Token = types.StringValue(token)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
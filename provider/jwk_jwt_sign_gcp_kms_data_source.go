@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"crypto"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+const gcpKmsUrl = "https://cloudkms.googleapis.com/v1/"
+
+var _ datasource.DataSource = &JwkJwtSignGcpKmsDataSource{}
+
+type JwkJwtSignGcpKmsDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkJwtSignGcpKmsDataSourceModel struct {
+	GoogleCredentialsModel
+	JweEncryptionModel
+	JwtClaimsModel
+	Algorithm  types.String   `tfsdk:"algorithm"`
+	Headers    types.Map      `tfsdk:"headers"`
+	Id         types.String   `tfsdk:"id"`
+	Jwk        types.String   `tfsdk:"jwk"`
+	KeyVersion types.String   `tfsdk:"key_version"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+	Token      types.String   `tfsdk:"token"`
+}
+
+type GcpKmsPublicKeyResp struct {
+	Algorithm string `json:"algorithm"`
+	Name      string `json:"name"`
+	Pem       string `json:"pem"`
+}
+
+type GcpKmsAsymmetricSignResp struct {
+	Signature []byte `json:"signature"`
+}
+
+func NewJwkJwtSignGcpKmsDataSource() datasource.DataSource {
+	return &JwkJwtSignGcpKmsDataSource{}
+}
+
+func (d *JwkJwtSignGcpKmsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_jwt_sign_gcp_kms"
+}
+
+func (d *JwkJwtSignGcpKmsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to sign a JWT with a GCP Cloud KMS asymmetric signing key, the private key never leaves Cloud KMS. The token is signed again, with new time claims, on every read",
+
+		Attributes: withGoogleCredentialsAttributes(withJweEncryptionAttributes(withJwtClaimsAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"key_version": schema.StringAttribute{
+				MarkdownDescription: "Resource name of the key version, `projects/<project>/locations/<location>/keyRings/<key_ring>/cryptoKeys/<key>/cryptoKeyVersions/<version>`",
+				Required:            true,
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "Signature algorithm, defaults to the algorithm of the key version",
+				Optional:            true,
+			},
+			"headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Extra protected headers",
+				Optional:            true,
+			},
+			"jwk": schema.StringAttribute{
+				MarkdownDescription: "Public JWK of the key version, its kid is the RFC 7638 thumbprint of the key",
+				Computed:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Compact serialized JWT, a JWE when `encryption_jwk` is set",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		}))),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkJwtSignGcpKmsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkJwtSignGcpKmsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkJwtSignGcpKmsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := d.provider.newHTTPClient(nil)
+
+	accessToken, err := data.accessToken(ctx, client, googleCloudPlatformScope)
+	if err != nil {
+		resp.Diagnostics.AddError("accessToken", fmt.Sprintf("Can't get Google access token : %s", err))
+		return
+	}
+	headers := map[string]string{"Authorization": "Bearer " + accessToken}
+
+	keyVersion := strings.TrimPrefix(data.KeyVersion.ValueString(), "/")
+	var publicKeyResp GcpKmsPublicKeyResp
+	err = jwkutil.GetJson(ctx, client, gcpKmsUrl+keyVersion+"/publicKey", headers, &publicKeyResp)
+	if err != nil {
+		resp.Diagnostics.AddError("GetPublicKey", fmt.Sprintf("Fail to get public key of %s : %s", keyVersion, err))
+		return
+	}
+
+	alg, err := gcpKmsSignatureAlgorithm(publicKeyResp.Algorithm)
+	if err != nil {
+		resp.Diagnostics.AddError("gcpKmsSignatureAlgorithm", fmt.Sprintf("Can't sign with %s : %s", keyVersion, err))
+		return
+	}
+
+	block, _ := pem.Decode([]byte(publicKeyResp.Pem))
+	if block == nil {
+		resp.Diagnostics.AddError("Decode", fmt.Sprintf("Can't decode public key of %s", keyVersion))
+		return
+	}
+	publicKey, err := jwkutil.PemBlockToJwk(block)
+	if err != nil {
+		resp.Diagnostics.AddError("PemBlockToJwk", fmt.Sprintf("Can't parse public key of %s : %s", keyVersion, err))
+		return
+	}
+
+	signer, err := newRemoteSigner(publicKey.Key, []jose.SignatureAlgorithm{alg}, true, func(alg jose.SignatureAlgorithm, digest []byte) ([]byte, error) {
+		hash, err := signatureHash(alg)
+		if err != nil {
+			return nil, err
+		}
+
+		var signResp GcpKmsAsymmetricSignResp
+		err = postJson(ctx, client, gcpKmsUrl+keyVersion+":asymmetricSign", headers, map[string]any{
+			"digest": map[string][]byte{gcpKmsDigestName(hash): digest},
+		}, &signResp)
+		return signResp.Signature, err
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("newRemoteSigner", fmt.Sprintf("Can't create signer : %s", err))
+		return
+	}
+	signer.public.Algorithm = string(alg)
+
+	token, jwk, diags := signer.signJwt(ctx, d.provider, data.Algorithm, data.Headers, data.JwtClaimsModel, data.JweEncryptionModel)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(sha256Hex([]byte(token)))
+	data.Jwk = types.StringValue(jwk)
+	data.Token = types.StringValue(token)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// gcpKmsSignatureAlgorithm returns the JWS algorithm of a Cloud KMS
+// asymmetric signing algorithm, such as EC_SIGN_P256_SHA256.
+func gcpKmsSignatureAlgorithm(algorithm string) (jose.SignatureAlgorithm, error) {
+	var family string
+	switch {
+	case strings.HasPrefix(algorithm, "RSA_SIGN_PKCS1_"):
+		family = "RS"
+	case strings.HasPrefix(algorithm, "RSA_SIGN_PSS_"):
+		family = "PS"
+	case strings.HasPrefix(algorithm, "EC_SIGN_P"):
+		family = "ES"
+	default:
+		return "", fmt.Errorf("unsupported key algorithm %s", algorithm)
+	}
+
+	for _, size := range []string{"256", "384", "512"} {
+		if strings.HasSuffix(algorithm, "_SHA"+size) {
+			return jose.SignatureAlgorithm(family + size), nil
+		}
+	}
+	return "", fmt.Errorf("unsupported key algorithm %s", algorithm)
+}
+
+// gcpKmsDigestName returns the name of the digest member for hash.
+func gcpKmsDigestName(hash crypto.Hash) string {
+	return strings.ReplaceAll(strings.ToLower(hash.String()), "-", "")
+}
@@ -0,0 +1,257 @@
+package provider
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ datasource.DataSource = &JwkJwtSignVaultTransitDataSource{}
+
+type JwkJwtSignVaultTransitDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkJwtSignVaultTransitDataSourceModel struct {
+	JweEncryptionModel
+	JwtClaimsModel
+	Address    types.String   `tfsdk:"address"`
+	Algorithm  types.String   `tfsdk:"algorithm"`
+	Headers    types.Map      `tfsdk:"headers"`
+	Id         types.String   `tfsdk:"id"`
+	Jwk        types.String   `tfsdk:"jwk"`
+	KeyName    types.String   `tfsdk:"key_name"`
+	KeyVersion types.Int64    `tfsdk:"key_version"`
+	Mount      types.String   `tfsdk:"mount"`
+	Namespace  types.String   `tfsdk:"namespace"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+	Token      types.String   `tfsdk:"token"`
+	VaultToken types.String   `tfsdk:"vault_token"`
+}
+
+type VaultTransitKeyResp struct {
+	Data struct {
+		Keys map[string]struct {
+			PublicKey string `json:"public_key"`
+		} `json:"keys"`
+		LatestVersion int64  `json:"latest_version"`
+		Type          string `json:"type"`
+	} `json:"data"`
+}
+
+type VaultTransitSignResp struct {
+	Data struct {
+		Signature string `json:"signature"`
+	} `json:"data"`
+}
+
+func NewJwkJwtSignVaultTransitDataSource() datasource.DataSource {
+	return &JwkJwtSignVaultTransitDataSource{}
+}
+
+func (d *JwkJwtSignVaultTransitDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_jwt_sign_vault_transit"
+}
+
+func (d *JwkJwtSignVaultTransitDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to sign a JWT with an RSA or ECDSA key of the Vault transit secrets engine, the private key never leaves Vault. The token is signed again, with new time claims, on every read",
+
+		Attributes: withJweEncryptionAttributes(withJwtClaimsAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"address": schema.StringAttribute{
+				MarkdownDescription: "Vault address, defaults to `VAULT_ADDR`",
+				Optional:            true,
+			},
+			"vault_token": schema.StringAttribute{
+				MarkdownDescription: "Vault token, defaults to `VAULT_TOKEN`",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "Vault namespace, defaults to `VAULT_NAMESPACE`",
+				Optional:            true,
+			},
+			"mount": schema.StringAttribute{
+				MarkdownDescription: "Mount path of the transit secrets engine, defaults to `transit`",
+				Optional:            true,
+			},
+			"key_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the transit key",
+				Required:            true,
+			},
+			"key_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of the transit key, defaults to the latest version",
+				Optional:            true,
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "Signature algorithm, defaults to the usual algorithm of the key type",
+				Optional:            true,
+			},
+			"headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Extra protected headers",
+				Optional:            true,
+			},
+			"jwk": schema.StringAttribute{
+				MarkdownDescription: "Public JWK of the transit key version, its kid is the RFC 7638 thumbprint of the key",
+				Computed:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Compact serialized JWT, a JWE when `encryption_jwk` is set",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		})),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkJwtSignVaultTransitDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkJwtSignVaultTransitDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkJwtSignVaultTransitDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	address, headers, err := vaultConfig(data.Address, data.VaultToken, data.Namespace)
+	if err != nil {
+		resp.Diagnostics.AddError("vaultConfig", fmt.Sprintf("Can't configure Vault : %s", err))
+		return
+	}
+
+	client := withHeaders(d.provider.newHTTPClient(nil), address, headers)
+
+	mount := strings.Trim(data.Mount.ValueString(), "/")
+	if mount == "" {
+		mount = "transit"
+	}
+	keyName := data.KeyName.ValueString()
+	base := fmt.Sprintf("%s/v1/%s", address, mount)
+
+	var keyResp VaultTransitKeyResp
+	err = jwkutil.GetJson(ctx, client, base+"/keys/"+url.PathEscape(keyName), nil, &keyResp)
+	if err != nil {
+		resp.Diagnostics.AddError("ReadKey", fmt.Sprintf("Fail to read transit key %s : %s", keyName, err))
+		return
+	}
+
+	keyVersion := keyResp.Data.LatestVersion
+	if !data.KeyVersion.IsNull() {
+		keyVersion = data.KeyVersion.ValueInt64()
+	}
+	version, ok := keyResp.Data.Keys[strconv.FormatInt(keyVersion, 10)]
+	if !ok {
+		resp.Diagnostics.AddError("ReadKey", fmt.Sprintf("Version %d of transit key %s not found", keyVersion, keyName))
+		return
+	}
+
+	block, _ := pem.Decode([]byte(version.PublicKey))
+	if block == nil {
+		resp.Diagnostics.AddError("Decode", fmt.Sprintf("Can't decode public key of transit key %s, %s keys are not supported", keyName, keyResp.Data.Type))
+		return
+	}
+	publicKey, err := jwkutil.PemBlockToJwk(block)
+	if err != nil {
+		resp.Diagnostics.AddError("PemBlockToJwk", fmt.Sprintf("Can't parse public key of transit key %s : %s", keyName, err))
+		return
+	}
+
+	var algs []jose.SignatureAlgorithm
+	switch {
+	case strings.HasPrefix(keyResp.Data.Type, "rsa-"):
+		algs = []jose.SignatureAlgorithm{jose.RS256, jose.RS384, jose.RS512, jose.PS256, jose.PS384, jose.PS512}
+	case strings.HasPrefix(keyResp.Data.Type, "ecdsa-"):
+		alg, err := jwkutil.SignatureAlgorithm(publicKey, "")
+		if err != nil {
+			resp.Diagnostics.AddError("SignatureAlgorithm", fmt.Sprintf("Can't sign with transit key %s : %s", keyName, err))
+			return
+		}
+		algs = []jose.SignatureAlgorithm{alg}
+	default:
+		resp.Diagnostics.AddError("ReadKey", fmt.Sprintf("Can't sign with transit key %s, %s keys are not supported", keyName, keyResp.Data.Type))
+		return
+	}
+
+	signer, err := newRemoteSigner(publicKey.Key, algs, false, func(alg jose.SignatureAlgorithm, digest []byte) ([]byte, error) {
+		hash, err := signatureHash(alg)
+		if err != nil {
+			return nil, err
+		}
+
+		// The jws marshaling returns ECDSA signatures as r || s, and PSS
+		// salts must be as long as the hash to match the JWS algorithms.
+		signReq := map[string]any{
+			"input":                base64.StdEncoding.EncodeToString(digest),
+			"key_version":          keyVersion,
+			"marshaling_algorithm": "jws",
+			"prehashed":            true,
+		}
+		if _, ok := publicKey.Key.(*rsa.PublicKey); ok {
+			signReq["signature_algorithm"] = "pkcs1v15"
+			if strings.HasPrefix(string(alg), "PS") {
+				signReq["signature_algorithm"] = "pss"
+				signReq["salt_length"] = "hash"
+			}
+		}
+
+		var signResp VaultTransitSignResp
+		err = postJson(ctx, client, fmt.Sprintf("%s/sign/%s/%s", base, url.PathEscape(keyName), vaultTransitHashAlgorithm(hash)), nil, signReq, &signResp)
+		if err != nil {
+			return nil, err
+		}
+
+		// Signatures are prefixed with vault:v<version>:
+		signature := signResp.Data.Signature
+		return base64.RawURLEncoding.DecodeString(signature[strings.LastIndex(signature, ":")+1:])
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("newRemoteSigner", fmt.Sprintf("Can't create signer : %s", err))
+		return
+	}
+
+	token, jwk, diags := signer.signJwt(ctx, d.provider, data.Algorithm, data.Headers, data.JwtClaimsModel, data.JweEncryptionModel)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(sha256Hex([]byte(token)))
+	data.Jwk = types.StringValue(jwk)
+	data.Token = types.StringValue(token)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// vaultTransitHashAlgorithm returns the transit name of hash.
+func vaultTransitHashAlgorithm(hash crypto.Hash) string {
+	return "sha2-" + strings.TrimPrefix(hash.String(), "SHA-")
+}
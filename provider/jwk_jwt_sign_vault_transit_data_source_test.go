@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestJwkJwtSignVaultTransitDataSourceRedirect(t *testing.T) {
+	other := newRecordingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	vault := newRecordingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, other.localhostUrl()+r.URL.Path, http.StatusFound)
+	})
+
+	server, schemas := newTestProviderServer(t)
+	readDataSource(t, server, schemas, "jwk_jwt_sign_vault_transit", map[string]tftypes.Value{
+		"address":     tftypes.NewValue(tftypes.String, vault.URL),
+		"key_name":    tftypes.NewValue(tftypes.String, "signing"),
+		"vault_token": tftypes.NewValue(tftypes.String, "vault-token"),
+	})
+
+	if got := vault.received("X-Vault-Token"); len(got) != 1 {
+		t.Errorf("Vault received the token %d times, want 1", len(got))
+	}
+	if other.requests() != 1 {
+		t.Errorf("redirect target served %d requests, want 1", other.requests())
+	}
+	if got := other.received("X-Vault-Token"); len(got) != 0 {
+		t.Errorf("redirect target received the Vault token %v", got)
+	}
+}
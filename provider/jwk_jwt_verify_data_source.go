@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &JwkJwtVerifyDataSource{}
+
+type JwkJwtVerifyDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkJwtVerifyDataSourceModel struct {
+	JwtClaimsOutputModel
+	JwtValidationModel
+	Id       types.String   `tfsdk:"id"`
+	Jwk      types.String   `tfsdk:"jwk"`
+	Jwks     types.List     `tfsdk:"jwks"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	Token    types.String   `tfsdk:"token"`
+}
+
+func NewJwkJwtVerifyDataSource() datasource.DataSource {
+	return &JwkJwtVerifyDataSource{}
+}
+
+func (d *JwkJwtVerifyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_jwt_verify"
+}
+
+func (d *JwkJwtVerifyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to verify a JWT against a JWK or a JWKS and read its claims",
+
+		Attributes: withJwtClaimsOutputAttributes(withJwtValidationAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Compact serialized JWT",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"jwk": schema.StringAttribute{
+				MarkdownDescription: "JWK or JWKS verifying the token, conflicts with `jwks`",
+				Optional:            true,
+				Sensitive:           true,
+				Validators:          []validator.String{jwkValidator{jwks: true}},
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKs verifying the token, conflicts with `jwk`",
+				Optional:            true,
+				Sensitive:           true,
+				Validators:          []validator.List{jwkListValidator{}},
+			},
+		})),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkJwtVerifyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkJwtVerifyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkJwtVerifyDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, err := keySet(data.Jwk, data.Jwks)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(keySetPath(data.Jwk), "keySet", withJwkHint(fmt.Sprintf("Can't read keys : %s", err), data.Jwk.ValueString(), true))
+		return
+	}
+	keys, err = d.provider.base64urlKeys(keys)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(keySetPath(data.Jwk), "base64urlKeys", fmt.Sprintf("Can't apply base64url handling : %s", err))
+		return
+	}
+	if err := d.provider.checkConformance(keys); err != nil {
+		resp.Diagnostics.AddAttributeError(keySetPath(data.Jwk), "checkConformance", fmt.Sprintf("Keys rejected by the strict conformance : %s", err))
+		return
+	}
+	d.provider.checkWeakKeys(&resp.Diagnostics, data.Jwk, keys)
+	d.provider.checkExpiringKeys(&resp.Diagnostics, data.Jwk, keys)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token := data.Token.ValueString()
+	data.JwtClaimsOutputModel, err = data.verifyJwt(d.provider, token, keys)
+	if err != nil {
+		resp.Diagnostics.AddError("verifyJwt", fmt.Sprintf("Fail to verify token : %s", err))
+		return
+	}
+	data.Id = types.StringValue(sha256Hex([]byte(token)))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
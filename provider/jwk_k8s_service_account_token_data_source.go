@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+const defaultK8sTokenExpiresIn = time.Hour
+
+var _ datasource.DataSource = &JwkK8sServiceAccountTokenDataSource{}
+
+type JwkK8sServiceAccountTokenDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkK8sServiceAccountTokenDataSourceModel struct {
+	Algorithm         types.String   `tfsdk:"algorithm"`
+	Audience          types.List     `tfsdk:"audience"`
+	ExpiresAt         types.String   `tfsdk:"expires_at"`
+	ExpiresIn         types.String   `tfsdk:"expires_in"`
+	Id                types.String   `tfsdk:"id"`
+	Issuer            types.String   `tfsdk:"issuer"`
+	Jwk               types.String   `tfsdk:"jwk"`
+	Namespace         types.String   `tfsdk:"namespace"`
+	NodeName          types.String   `tfsdk:"node_name"`
+	NodeUid           types.String   `tfsdk:"node_uid"`
+	PodName           types.String   `tfsdk:"pod_name"`
+	PodUid            types.String   `tfsdk:"pod_uid"`
+	ServiceAccount    types.String   `tfsdk:"service_account"`
+	ServiceAccountUid types.String   `tfsdk:"service_account_uid"`
+	Subject           types.String   `tfsdk:"subject"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+	Token             types.String   `tfsdk:"token"`
+}
+
+func NewJwkK8sServiceAccountTokenDataSource() datasource.DataSource {
+	return &JwkK8sServiceAccountTokenDataSource{}
+}
+
+func (d *JwkK8sServiceAccountTokenDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_k8s_service_account_token"
+}
+
+func (d *JwkK8sServiceAccountTokenDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to mint a K8S service account token, with the claims of the tokens returned by the TokenRequest API, signed with the service account issuer private JWK. The token is signed again, with new time claims, on every read",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"jwk": schema.StringAttribute{
+				MarkdownDescription: "Private JWK of the service account issuer. The kid defaults to the one K8S derives from the key",
+				Required:            true,
+				Sensitive:           true,
+				Validators:          []validator.String{jwkValidator{private: true}},
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "Signature algorithm, defaults to the `alg` of the JWK or to the usual algorithm of its key type",
+				Optional:            true,
+			},
+			"issuer": schema.StringAttribute{
+				MarkdownDescription: "Service account issuer, `iss` claim",
+				Required:            true,
+			},
+			"audience": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "`aud` claim",
+				Required:            true,
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "Namespace of the service account",
+				Required:            true,
+			},
+			"service_account": schema.StringAttribute{
+				MarkdownDescription: "Name of the service account",
+				Required:            true,
+			},
+			"service_account_uid": schema.StringAttribute{
+				MarkdownDescription: "UID of the service account",
+				Optional:            true,
+			},
+			"pod_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the pod the token is bound to",
+				Optional:            true,
+			},
+			"pod_uid": schema.StringAttribute{
+				MarkdownDescription: "UID of the pod the token is bound to",
+				Optional:            true,
+			},
+			"node_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the node the token is bound to",
+				Optional:            true,
+			},
+			"node_uid": schema.StringAttribute{
+				MarkdownDescription: "UID of the node the token is bound to",
+				Optional:            true,
+			},
+			"expires_in": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Duration after which the token expires (default: `%s`)", defaultK8sTokenExpiresIn),
+				Optional:            true,
+			},
+			"subject": schema.StringAttribute{
+				MarkdownDescription: "`sub` claim, `system:serviceaccount:<namespace>:<service_account>`",
+				Computed:            true,
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "`exp` claim in RFC 3339 format",
+				Computed:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Compact serialized service account token",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkK8sServiceAccountTokenDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkK8sServiceAccountTokenDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkK8sServiceAccountTokenDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jwkStr, err := d.provider.base64urlJwk(data.Jwk.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "base64urlJwk", fmt.Sprintf("Can't apply base64url handling : %s", err))
+		return
+	}
+	if err := d.provider.checkConformanceJwk(jwkStr); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "checkConformanceJwk", fmt.Sprintf("JWK rejected by the strict conformance : %s", err))
+		return
+	}
+	key, err := signingKey(jwkStr, data.Algorithm.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "signingKey", withJwkHint(fmt.Sprintf("Can't load signing key : %s", err), jwkStr, false))
+		return
+	}
+	if err := d.provider.checkSigningKey(key); err != nil {
+		resp.Diagnostics.AddError("checkSigningKey", fmt.Sprintf("Signing key rejected by the provider policy : %s", err))
+		return
+	}
+	d.provider.checkWeakSigningKey(&resp.Diagnostics, path.Root("jwk"), key)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if jwk := key.Key.(jose.JSONWebKey); jwk.KeyID == "" {
+		jwk.KeyID, err = jwkutil.K8sKeyId(jwk.Public().Key)
+		if err != nil {
+			resp.Diagnostics.AddError("K8sKeyId", fmt.Sprintf("Can't derive kid : %s", err))
+			return
+		}
+		key.Key = jwk
+	}
+
+	expiresIn, err := parseDuration(data.ExpiresIn, defaultK8sTokenExpiresIn)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("expires_in"), "parseDuration", fmt.Sprintf("Invalid expires_in : %s", err))
+		return
+	}
+
+	jti, err := randomUuid()
+	if err != nil {
+		resp.Diagnostics.AddError("randomUuid", fmt.Sprintf("Can't generate jti : %s", err))
+		return
+	}
+
+	var audience []string
+	resp.Diagnostics.Append(data.Audience.ElementsAs(ctx, &audience, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespace := data.Namespace.ValueString()
+	serviceAccount := data.ServiceAccount.ValueString()
+	subject := fmt.Sprintf("system:serviceaccount:%s:%s", namespace, serviceAccount)
+
+	private := map[string]any{
+		"namespace":      namespace,
+		"serviceaccount": k8sObjectReference(serviceAccount, data.ServiceAccountUid),
+	}
+	if podName := data.PodName.ValueString(); podName != "" {
+		private["pod"] = k8sObjectReference(podName, data.PodUid)
+	}
+	if nodeName := data.NodeName.ValueString(); nodeName != "" {
+		private["node"] = k8sObjectReference(nodeName, data.NodeUid)
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(expiresIn)
+	claims := map[string]any{
+		"aud":           audience,
+		"exp":           expiresAt.Unix(),
+		"iat":           now.Unix(),
+		"iss":           data.Issuer.ValueString(),
+		"jti":           jti,
+		"kubernetes.io": private,
+		"nbf":           now.Unix(),
+		"sub":           subject,
+	}
+
+	signer, err := newJwtSigner(key, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("NewSigner", fmt.Sprintf("Can't create signer : %s", err))
+		return
+	}
+
+	token, err := signJwt(signer, claims)
+	if err != nil {
+		resp.Diagnostics.AddError("signJwt", fmt.Sprintf("Can't sign token : %s", err))
+		return
+	}
+
+	data.ExpiresAt = types.StringValue(expiresAt.UTC().Format(time.RFC3339))
+	data.Id = types.StringValue(sha256Hex([]byte(token)))
+	data.Subject = types.StringValue(subject)
+	data.Token = types.StringValue(token)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// k8sObjectReference returns the reference to an object in the private
+// claims of a service account token.
+func k8sObjectReference(name string, uid types.String) map[string]string {
+	ref := map[string]string{"name": name}
+	if uid.ValueString() != "" {
+		ref["uid"] = uid.ValueString()
+	}
+	return ref
+}
+
+// randomUuid returns a random version 4 UUID.
+func randomUuid() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ datasource.DataSource = &JwkKidCollisionsDataSource{}
+
+type JwkKidCollisionsDataSource struct{}
+
+type JwkKidCollisionsDataSourceModel struct {
+	Collisions      types.Map      `tfsdk:"collisions"`
+	Documents       types.List     `tfsdk:"documents"`
+	FailOnCollision types.Bool     `tfsdk:"fail_on_collision"`
+	HasCollisions   types.Bool     `tfsdk:"has_collisions"`
+	Id              types.String   `tfsdk:"id"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewJwkKidCollisionsDataSource() datasource.DataSource {
+	return &JwkKidCollisionsDataSource{}
+}
+
+func (d *JwkKidCollisionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kid_collisions"
+}
+
+func (d *JwkKidCollisionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to find the kids shared by different keys among several JWKS, such as the ones of the issuers behind a single gateway, which break the kid based key selection of verifiers. The same key published under the same kid isn't a collision",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"documents": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKS or JWKs",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"fail_on_collision": schema.BoolAttribute{
+				MarkdownDescription: "Fail when a collision is found",
+				Optional:            true,
+			},
+			"collisions": schema.MapAttribute{
+				ElementType:         types.ListType{ElemType: types.Int64Type},
+				MarkdownDescription: "Indexes in `documents` of the documents holding different keys with the same kid, by kid",
+				Computed:            true,
+			},
+			"has_collisions": schema.BoolAttribute{
+				MarkdownDescription: "Whether a collision is found",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkKidCollisionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+}
+
+func (d *JwkKidCollisionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkKidCollisionsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var documents []string
+	resp.Diagnostics.Append(data.Documents.ElementsAs(ctx, &documents, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Public forms of the keys of every kid, along with the documents holding
+	// them.
+	type kidKey struct {
+		documents []int64
+		public    string
+	}
+	kidKeys := map[string][]*kidKey{}
+	var kids []string
+
+	for i, document := range documents {
+		keys, err := jwkutil.ParseJwks([]byte(document))
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("documents").AtListIndex(i), "ParseJwks", withJwkHint(fmt.Sprintf("Can't parse JWKS : %s", err), document, true))
+			return
+		}
+
+		for j, key := range keys {
+			jwk, err := jwkutil.ParseJwk(string(key))
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("documents").AtListIndex(i), "ParseJwk", fmt.Sprintf("Can't unmarshal key #%d : %s", j, err))
+				return
+			}
+			if jwk.KeyID == "" {
+				continue
+			}
+			public, err := jwkutil.PublicKey(jwk).MarshalJSON()
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("documents").AtListIndex(i), "MarshalJSON", fmt.Sprintf("Can't marshal key #%d : %s", j, err))
+				return
+			}
+
+			if _, ok := kidKeys[jwk.KeyID]; !ok {
+				kids = append(kids, jwk.KeyID)
+			}
+			index := slices.IndexFunc(kidKeys[jwk.KeyID], func(k *kidKey) bool { return k.public == string(public) })
+			if index < 0 {
+				kidKeys[jwk.KeyID] = append(kidKeys[jwk.KeyID], &kidKey{public: string(public)})
+				index = len(kidKeys[jwk.KeyID]) - 1
+			}
+			if k := kidKeys[jwk.KeyID][index]; !slices.Contains(k.documents, int64(i)) {
+				k.documents = append(k.documents, int64(i))
+			}
+		}
+	}
+
+	collisions := map[string][]int64{}
+	for _, kid := range kids {
+		if len(kidKeys[kid]) < 2 {
+			continue
+		}
+
+		var indexes []int64
+		for _, k := range kidKeys[kid] {
+			indexes = append(indexes, k.documents...)
+		}
+		slices.Sort(indexes)
+		collisions[kid] = slices.Compact(indexes)
+
+		var documentIndexes []string
+		for _, index := range collisions[kid] {
+			documentIndexes = append(documentIndexes, strconv.FormatInt(index, 10))
+		}
+		detail := fmt.Sprintf("Kid %q is shared by %d different keys in documents %s", kid, len(kidKeys[kid]), strings.Join(documentIndexes, ", "))
+		if data.FailOnCollision.ValueBool() {
+			resp.Diagnostics.AddAttributeError(path.Root("documents"), "Kid collision", detail)
+		} else {
+			resp.Diagnostics.AddAttributeWarning(path.Root("documents"), "Kid collision", detail)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var diags diag.Diagnostics
+	data.Collisions, diags = types.MapValueFrom(ctx, types.ListType{ElemType: types.Int64Type}, collisions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.HasCollisions = types.BoolValue(len(collisions) > 0)
+	data.Id = types.StringValue(sha256Hex([]byte(strings.Join(documents, "\n"))))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ function.Function = &JwkKidFunction{}
+
+type JwkKidFunction struct{}
+
+func NewJwkKidFunction() function.Function {
+	return &JwkKidFunction{}
+}
+
+func (f *JwkKidFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "kid"
+}
+
+func (f *JwkKidFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Key ID of a JWK",
+		MarkdownDescription: "Returns the `kid` of a JWK, or its base64url encoded RFC 7638 SHA-256 thumbprint when it has none, like the ID of the `jwk_to_pem` data source",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "jwk",
+				MarkdownDescription: "JWK",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *JwkKidFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var jwkStr string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &jwkStr))
+	if resp.Error != nil {
+		return
+	}
+
+	jwk, err := jwkutil.ParseJwk(jwkStr)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, withJwkHint(fmt.Sprintf("Can't unmarshal JWK : %s", err), jwkStr, false))
+		return
+	}
+
+	kid, err := keyIdOrThumbprint(jwk)
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Can't compute thumbprint : %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, kid))
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ function.Function = &JwkMergeJwksFunction{}
+
+type JwkMergeJwksFunction struct{}
+
+func NewJwkMergeJwksFunction() function.Function {
+	return &JwkMergeJwksFunction{}
+}
+
+func (f *JwkMergeJwksFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "merge_jwks"
+}
+
+func (f *JwkMergeJwksFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Merge JWKS",
+		MarkdownDescription: "Returns a JWKS holding the keys of every JWKS or JWK of `jwks`, in order. Keys are canonicalized and only the first occurrence of identical keys is kept",
+
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				ElementType:         types.StringType,
+				Name:                "jwks",
+				MarkdownDescription: "List of JWKS or JWKs",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *JwkMergeJwksFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var jwksList []string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &jwksList))
+	if resp.Error != nil {
+		return
+	}
+
+	merged := []json.RawMessage{}
+	seen := map[string]bool{}
+	for i, jwksStr := range jwksList {
+		keys, err := jwkutil.ParseJwks([]byte(jwksStr))
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(0, withJwkHint(fmt.Sprintf("Can't parse JWKS #%d : %s", i, err), jwksStr, true))
+			return
+		}
+
+		for j, key := range keys {
+			canonical, err := canonicalKey(key)
+			if err != nil {
+				resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Can't unmarshal key #%d of JWKS #%d : %s", j, i, err))
+				return
+			}
+			if seen[string(canonical)] {
+				continue
+			}
+			seen[string(canonical)] = true
+			merged = append(merged, canonical)
+		}
+	}
+
+	jwksData, err := json.Marshal(JwksResp{Keys: merged})
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Can't marshal JwksResp : %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, string(jwksData)))
+}
@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &JwkOidcIdTokenVerifyDataSource{}
+
+type JwkOidcIdTokenVerifyDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkOidcIdTokenVerifyDataSourceModel struct {
+	FetchOptionsModel
+	JwtClaimsOutputModel
+	JwtValidationModel
+	Id        types.String   `tfsdk:"id"`
+	IssuerUrl types.String   `tfsdk:"issuer_url"`
+	JwksUri   types.String   `tfsdk:"jwks_uri"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+	Token     types.String   `tfsdk:"token"`
+}
+
+func NewJwkOidcIdTokenVerifyDataSource() datasource.DataSource {
+	return &JwkOidcIdTokenVerifyDataSource{}
+}
+
+func (d *JwkOidcIdTokenVerifyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oidc_id_token_verify"
+}
+
+func (d *JwkOidcIdTokenVerifyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to verify an ID token against the JWKS of its OIDC issuer and read its claims. `iss`, `aud` and `exp` are always validated",
+
+		Attributes: withFetchOptionsAttributes(withJwtClaimsOutputAttributes(withJwtValidationAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"issuer_url": schema.StringAttribute{
+				MarkdownDescription: "OIDC issuer URL, the OIDC discovery document is fetched from `<issuer_url>/.well-known/openid-configuration`",
+				Required:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Compact serialized ID token",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"jwks_uri": schema.StringAttribute{
+				MarkdownDescription: "JWKS URI of the issuer",
+				Computed:            true,
+			},
+		}))),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkOidcIdTokenVerifyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkOidcIdTokenVerifyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkOidcIdTokenVerifyDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ExpectedAudience.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("expected_audience"), "expected_audience", "The client ID must be set in expected_audience to validate an ID token")
+		return
+	}
+
+	discovery, keys, diags := fetchOidcJwks(ctx, d.provider.newHTTPClient(nil), data.IssuerUrl.ValueString(), data.FetchOptionsModel)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validation := data.JwtValidationModel
+	if validation.ExpectedIssuer.ValueString() == "" {
+		validation.ExpectedIssuer = types.StringValue(discovery.Issuer)
+	}
+	if validation.Algorithms.IsNull() && len(discovery.IdTokenSigningAlgValuesSupported) > 0 {
+		// The discovered algorithms can only narrow the provider ones.
+		allowed, _ := d.provider.signatureAlgorithms(types.ListNull(types.StringType))
+		algorithms := []string{}
+		for _, alg := range discovery.IdTokenSigningAlgValuesSupported {
+			if slices.Contains(allowed, jose.SignatureAlgorithm(alg)) {
+				algorithms = append(algorithms, alg)
+			}
+		}
+		validation.Algorithms, diags = types.ListValueFrom(ctx, types.StringType, algorithms)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	token := data.Token.ValueString()
+	claims, err := validation.verifyJwt(d.provider, token, keys)
+	if err != nil {
+		resp.Diagnostics.AddError("verifyJwt", fmt.Sprintf("Fail to verify ID token : %s", err))
+		return
+	}
+	if claims.ExpiresAt.IsNull() {
+		resp.Diagnostics.AddError("verifyJwt", "Fail to verify ID token : no exp claim")
+		return
+	}
+
+	data.JwtClaimsOutputModel = claims
+	data.Id = types.StringValue(sha256Hex([]byte(token)))
+	data.JwksUri = types.StringValue(discovery.JwksUri)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
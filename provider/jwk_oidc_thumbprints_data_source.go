@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ datasource.DataSource = &JwkOidcThumbprintsDataSource{}
+
+type JwkOidcThumbprintsDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkOidcThumbprintsDataSourceModel struct {
+	Id          types.String   `tfsdk:"id"`
+	Thumbprints types.List     `tfsdk:"thumbprints"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+	Url         types.String   `tfsdk:"url"`
+	UseJwksUri  types.Bool     `tfsdk:"use_jwks_uri"`
+}
+
+func NewJwkOidcThumbprintsDataSource() datasource.DataSource {
+	return &JwkOidcThumbprintsDataSource{}
+}
+
+func (d *JwkOidcThumbprintsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oidc_thumbprints"
+}
+
+func (d *JwkOidcThumbprintsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to compute the CA thumbprints of an OIDC issuer expected by `aws_iam_openid_connect_provider`",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "URL of the OIDC issuer",
+				Required:            true,
+			},
+			"use_jwks_uri": schema.BoolAttribute{
+				MarkdownDescription: "Compute the thumbprints of the host serving the `jwks_uri` discovered from the issuer, as AWS IAM does, instead of the issuer host",
+				Optional:            true,
+			},
+			"thumbprints": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "SHA-1 thumbprints of the CA certificates presented by the host, the top of the chain first",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkOidcThumbprintsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkOidcThumbprintsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkOidcThumbprintsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := data.Url.ValueString()
+	if data.UseJwksUri.ValueBool() {
+		discovery, err := jwkutil.FetchOidcDiscovery(ctx, d.provider.newHTTPClient(nil), url)
+		if err != nil {
+			resp.Diagnostics.AddError("fetchOidcDiscovery", fmt.Sprintf("Fail to fetch OIDC discovery document : %s", err))
+			return
+		}
+		url = discovery.JwksUri
+	}
+
+	thumbprints, err := caThumbprints(ctx, url, d.provider)
+	if err != nil {
+		resp.Diagnostics.AddError("caThumbprints", fmt.Sprintf("Fail to compute CA thumbprints of %s : %s", url, err))
+		return
+	}
+
+	var diags diag.Diagnostics
+	data.Thumbprints, diags = types.ListValueFrom(ctx, types.StringType, thumbprints)
+	resp.Diagnostics.Append(diags...)
+	data.Id = types.StringValue(data.Url.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
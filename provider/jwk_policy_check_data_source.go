@@ -0,0 +1,252 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+const (
+	enforcementError   = "error"
+	enforcementWarning = "warning"
+)
+
+var _ datasource.DataSource = &JwkPolicyCheckDataSource{}
+
+type JwkPolicyCheckDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkPolicyCheckDataSourceModel struct {
+	AllowedAlgorithms types.List     `tfsdk:"allowed_algorithms"`
+	AllowedCurves     types.List     `tfsdk:"allowed_curves"`
+	Compliant         types.Bool     `tfsdk:"compliant"`
+	Enforcement       types.String   `tfsdk:"enforcement"`
+	Fips              types.Bool     `tfsdk:"fips"`
+	Id                types.String   `tfsdk:"id"`
+	Jwk               types.String   `tfsdk:"jwk"`
+	Jwks              types.List     `tfsdk:"jwks"`
+	MinRsaBits        types.Int64    `tfsdk:"min_rsa_bits"`
+	RequireKid        types.Bool     `tfsdk:"require_kid"`
+	RequiredUse       types.String   `tfsdk:"required_use"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+	Violations        types.List     `tfsdk:"violations"`
+}
+
+func NewJwkPolicyCheckDataSource() datasource.DataSource {
+	return &JwkPolicyCheckDataSource{}
+}
+
+func (d *JwkPolicyCheckDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy_check"
+}
+
+func (d *JwkPolicyCheckDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to check keys against a crypto policy, failing or warning on the keys violating it. Unset rules allow every key",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"jwk": schema.StringAttribute{
+				MarkdownDescription: "JWK or JWKS to check, conflicts with `jwks`",
+				Optional:            true,
+				Sensitive:           true,
+				Validators:          []validator.String{jwkValidator{jwks: true}},
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKs to check, conflicts with `jwk`",
+				Optional:            true,
+				Sensitive:           true,
+				Validators:          []validator.List{jwkListValidator{}},
+			},
+			"min_rsa_bits": schema.Int64Attribute{
+				MarkdownDescription: "Minimum size of RSA keys",
+				Optional:            true,
+			},
+			"allowed_curves": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Curves EC and OKP keys can use",
+				Optional:            true,
+			},
+			"allowed_algorithms": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Algorithms keys can declare in `alg`, keys without `alg` violating the policy",
+				Optional:            true,
+			},
+			"required_use": schema.StringAttribute{
+				MarkdownDescription: "Public key use keys must declare in `use`, `sig` or `enc`",
+				Optional:            true,
+			},
+			"require_kid": schema.BoolAttribute{
+				MarkdownDescription: "Require keys to have a `kid`",
+				Optional:            true,
+			},
+			"fips": schema.BoolAttribute{
+				MarkdownDescription: "Also require the key sizes, curves and algorithms approved by FIPS 140-3 and FIPS 186-5, regardless of the provider `fips_mode`",
+				Optional:            true,
+			},
+			"enforcement": schema.StringAttribute{
+				MarkdownDescription: "Severity of the diagnostics reporting violations, `error` or `warning` (default: `error`)",
+				Optional:            true,
+			},
+			"compliant": schema.BoolAttribute{
+				MarkdownDescription: "Whether every key complies with the policy",
+				Computed:            true,
+			},
+			"violations": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Violations of the policy",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkPolicyCheckDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkPolicyCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkPolicyCheckDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	enforcement := data.Enforcement.ValueString()
+	if enforcement == "" {
+		enforcement = enforcementError
+	}
+	if enforcement != enforcementError && enforcement != enforcementWarning {
+		resp.Diagnostics.AddAttributeError(path.Root("enforcement"), "enforcement", fmt.Sprintf("Unsupported enforcement %q, expected error or warning", enforcement))
+		return
+	}
+	if data.MinRsaBits.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("min_rsa_bits"), "min_rsa_bits", "min_rsa_bits can't be negative")
+		return
+	}
+
+	keys, err := keySet(data.Jwk, data.Jwks)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(keySetPath(data.Jwk), "keySet", withJwkHint(fmt.Sprintf("Can't read keys : %s", err), data.Jwk.ValueString(), true))
+		return
+	}
+	keys, err = d.provider.base64urlKeys(keys)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(keySetPath(data.Jwk), "base64urlKeys", fmt.Sprintf("Can't apply base64url handling : %s", err))
+		return
+	}
+	if err := d.provider.checkConformance(keys); err != nil {
+		resp.Diagnostics.AddAttributeError(keySetPath(data.Jwk), "checkConformance", fmt.Sprintf("Keys rejected by the strict conformance : %s", err))
+		return
+	}
+
+	violations := []string{}
+	for i, key := range keys {
+		for _, violation := range data.violations(key) {
+			detail := fmt.Sprintf("Key %d : %s", i, violation)
+			if kid, err := jwkutil.Kid(key); err == nil && kid != "" {
+				detail = fmt.Sprintf("Key %q : %s", kid, violation)
+			}
+			violations = append(violations, detail)
+
+			if enforcement == enforcementError {
+				resp.Diagnostics.AddAttributeError(keySetIndexPath(data.Jwk, i), "Policy violation", detail)
+			} else {
+				resp.Diagnostics.AddAttributeWarning(keySetIndexPath(data.Jwk, i), "Policy violation", detail)
+			}
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jwksData, err := json.Marshal(JwksResp{Keys: keys})
+	if err != nil {
+		resp.Diagnostics.AddError("Marshal", fmt.Sprintf("Can't marshal JwksResp : %s", err))
+		return
+	}
+
+	var diags diag.Diagnostics
+	data.Violations, diags = types.ListValueFrom(ctx, types.StringType, violations)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Compliant = types.BoolValue(len(violations) == 0)
+	data.Id = types.StringValue(sha256Hex(jwksData))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// violations returns how key violates the policy of m.
+func (m JwkPolicyCheckDataSourceModel) violations(key json.RawMessage) []string {
+	jwk, err := jwkutil.ParseJwk(string(key))
+	if err != nil {
+		return []string{fmt.Sprintf("can't unmarshal the key: %s", err)}
+	}
+
+	var violations []string
+	policy := keyPolicy{minRsaBits: int(m.MinRsaBits.ValueInt64())}
+	for _, value := range m.AllowedCurves.Elements() {
+		policy.allowedCurves = append(policy.allowedCurves, value.(types.String).ValueString())
+	}
+	if err := policy.checkKey(jwk.Key); err != nil {
+		violations = append(violations, err.Error())
+	}
+
+	if m.Fips.ValueBool() {
+		if err := checkFipsKey(jwk.Key, "fips"); err != nil {
+			violations = append(violations, err.Error())
+		}
+		if err := checkFipsAlgorithm(jwk.Algorithm, "fips"); err != nil {
+			violations = append(violations, err.Error())
+		}
+	}
+
+	if !m.AllowedAlgorithms.IsNull() {
+		var algorithms []string
+		for _, value := range m.AllowedAlgorithms.Elements() {
+			algorithms = append(algorithms, value.(types.String).ValueString())
+		}
+		switch {
+		case jwk.Algorithm == "":
+			violations = append(violations, "the key has no alg, allowed_algorithms requires one")
+		case !slices.Contains(algorithms, jwk.Algorithm):
+			violations = append(violations, fmt.Sprintf("the %s algorithm isn't listed in allowed_algorithms, use one of %v", jwk.Algorithm, algorithms))
+		}
+	}
+
+	if use := m.RequiredUse.ValueString(); use != "" && jwk.Use != use {
+		violations = append(violations, fmt.Sprintf("the key use is %q, required_use requires %q", jwk.Use, use))
+	}
+	if m.RequireKid.ValueBool() && jwk.KeyID == "" {
+		violations = append(violations, "the key has no kid, require_kid requires one")
+	}
+
+	return violations
+}
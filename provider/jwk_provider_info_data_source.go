@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &JwkProviderInfoDataSource{}
+
+type JwkProviderInfoDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkProviderInfoDataSourceModel struct {
+	ContentEncryptionAlgorithms types.List     `tfsdk:"content_encryption_algorithms"`
+	Curves                      types.List     `tfsdk:"curves"`
+	Features                    types.Map      `tfsdk:"features"`
+	Id                          types.String   `tfsdk:"id"`
+	KeyEncryptionAlgorithms     types.List     `tfsdk:"key_encryption_algorithms"`
+	SignatureAlgorithms         types.List     `tfsdk:"signature_algorithms"`
+	Timeouts                    timeouts.Value `tfsdk:"timeouts"`
+	Version                     types.String   `tfsdk:"version"`
+}
+
+func NewJwkProviderInfoDataSource() datasource.DataSource {
+	return &JwkProviderInfoDataSource{}
+}
+
+func (d *JwkProviderInfoDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_provider_info"
+}
+
+func (d *JwkProviderInfoDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to read the version, the algorithms, the curves and the features of the configured provider, to gate module behavior on provider capabilities",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Version of the provider",
+				Computed:            true,
+			},
+			"signature_algorithms": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Signature algorithms accepted by default, after the provider `signature_algorithms`, `fips_mode` and `banned_algorithms`",
+				Computed:            true,
+			},
+			"key_encryption_algorithms": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Key management algorithms accepted by default, after the provider `key_encryption_algorithms`, `fips_mode` and `banned_algorithms`",
+				Computed:            true,
+			},
+			"content_encryption_algorithms": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Content encryption algorithms accepted by default, after the provider `content_encryption_algorithms` and `banned_algorithms`",
+				Computed:            true,
+			},
+			"curves": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Curves of the keys the data sources sign, encrypt or convert with, after the provider `allowed_curves`",
+				Computed:            true,
+			},
+			"features": schema.MapAttribute{
+				ElementType:         types.BoolType,
+				MarkdownDescription: "Features of the provider: `debug_http`, `disallow_private_output`, `ephemeral_resources`, `fips_mode`, `functions`, `mock_fixtures` and `offline`",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkProviderInfoDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkProviderInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkProviderInfoDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	signatureAlgorithms, err := d.provider.signatureAlgorithms(types.ListNull(types.StringType))
+	if err != nil {
+		resp.Diagnostics.AddError("signatureAlgorithms", fmt.Sprintf("Invalid signature algorithms : %s", err))
+		return
+	}
+	keyAlgorithms, contentEncryptions, err := d.provider.encryptionAlgorithms(types.ListNull(types.StringType), types.ListNull(types.StringType))
+	if err != nil {
+		resp.Diagnostics.AddError("encryptionAlgorithms", fmt.Sprintf("Invalid encryption algorithms : %s", err))
+		return
+	}
+
+	var diags diag.Diagnostics
+	data.SignatureAlgorithms, diags = types.ListValueFrom(ctx, types.StringType, signatureAlgorithms)
+	resp.Diagnostics.Append(diags...)
+	data.KeyEncryptionAlgorithms, diags = types.ListValueFrom(ctx, types.StringType, keyAlgorithms)
+	resp.Diagnostics.Append(diags...)
+	data.ContentEncryptionAlgorithms, diags = types.ListValueFrom(ctx, types.StringType, contentEncryptions)
+	resp.Diagnostics.Append(diags...)
+	data.Curves, diags = types.ListValueFrom(ctx, types.StringType, d.provider.curves())
+	resp.Diagnostics.Append(diags...)
+	data.Features, diags = types.MapValueFrom(ctx, types.BoolType, d.provider.features())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue("jwk")
+	data.Version = types.StringValue(d.provider.providerVersion())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// curves returns the supported curves allowed by the provider policy.
+func (p *JwkProviderData) curves() []string {
+	if p == nil || len(p.keyPolicy.allowedCurves) == 0 {
+		return supportedCurves
+	}
+	return slices.DeleteFunc(slices.Clone(supportedCurves), func(curve string) bool {
+		return !slices.Contains(p.keyPolicy.allowedCurves, curve)
+	})
+}
+
+// features returns the features provider_info reports.
+func (p *JwkProviderData) features() map[string]bool {
+	features := map[string]bool{
+		"debug_http":              false,
+		"disallow_private_output": false,
+		"ephemeral_resources":     true,
+		"fips_mode":               false,
+		"functions":               true,
+		"mock_fixtures":           os.Getenv(mockFixturesEnv) != "",
+		"offline":                 false,
+	}
+	if p != nil {
+		features["debug_http"] = p.debugHttp
+		features["disallow_private_output"] = p.disallowPrivateOutput
+		features["fips_mode"] = p.fipsMode
+		features["offline"] = p.offline
+	}
+	return features
+}
+
+// providerVersion returns the version of the provider, empty when it isn't
+// configured.
+func (p *JwkProviderData) providerVersion() string {
+	if p == nil {
+		return ""
+	}
+	return p.version
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ function.Function = &JwkPublicFunction{}
+
+type JwkPublicFunction struct{}
+
+func NewJwkPublicFunction() function.Function {
+	return &JwkPublicFunction{}
+}
+
+func (f *JwkPublicFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "public"
+}
+
+func (f *JwkPublicFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Public form of a JWK",
+		MarkdownDescription: "Returns a JWK without its private members, public JWKs being returned as is. Symmetric keys, which have no public form, are rejected",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "jwk",
+				MarkdownDescription: "JWK",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *JwkPublicFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var jwkStr string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &jwkStr))
+	if resp.Error != nil {
+		return
+	}
+
+	jwk, err := jwkutil.ParseJwk(jwkStr)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, withJwkHint(fmt.Sprintf("Can't unmarshal JWK : %s", err), jwkStr, false))
+		return
+	}
+	if _, ok := jwk.Key.([]byte); ok {
+		resp.Error = function.NewArgumentFuncError(0, "Symmetric keys have no public form")
+		return
+	}
+
+	jwkData, err := jwkutil.PublicKey(jwk).MarshalJSON()
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Can't marshal JWK : %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, string(jwkData)))
+}
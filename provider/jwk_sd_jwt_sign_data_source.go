@@ -0,0 +1,239 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ datasource.DataSource = &JwkSdJwtSignDataSource{}
+
+type JwkSdJwtSignDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkSdJwtSignDataSourceModel struct {
+	JwtClaimsModel
+	Algorithm       types.String   `tfsdk:"algorithm"`
+	Disclosures     types.List     `tfsdk:"disclosures"`
+	Headers         types.Map      `tfsdk:"headers"`
+	HolderJwk       types.String   `tfsdk:"holder_jwk"`
+	Id              types.String   `tfsdk:"id"`
+	Jwk             types.String   `tfsdk:"jwk"`
+	SdJwt           types.String   `tfsdk:"sd_jwt"`
+	SelectiveClaims types.String   `tfsdk:"selective_claims"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+	Token           types.String   `tfsdk:"token"`
+}
+
+func NewJwkSdJwtSignDataSource() datasource.DataSource {
+	return &JwkSdJwtSignDataSource{}
+}
+
+func (d *JwkSdJwtSignDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sd_jwt_sign"
+}
+
+func (d *JwkSdJwtSignDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to issue an SD-JWT (RFC 9901) with selectively disclosable claims, signed with the issuer private JWK. The token is signed again, with new salts and time claims, on every read",
+
+		Attributes: withJwtClaimsAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"jwk": schema.StringAttribute{
+				MarkdownDescription: "Private JWK of the issuer",
+				Required:            true,
+				Sensitive:           true,
+				Validators:          []validator.String{jwkValidator{private: true}},
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "Signature algorithm, defaults to the `alg` of the JWK or to the usual algorithm of its key type",
+				Optional:            true,
+			},
+			"headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Extra protected headers, e.g. `typ`",
+				Optional:            true,
+			},
+			"selective_claims": schema.StringAttribute{
+				MarkdownDescription: "JSON encoded claims, each of them is replaced with a digest in `_sd` and disclosed separately",
+				Required:            true,
+			},
+			"holder_jwk": schema.StringAttribute{
+				MarkdownDescription: "JWK of the holder, set in the `cnf` claim to require key binding",
+				Optional:            true,
+				Sensitive:           true,
+				Validators:          []validator.String{jwkValidator{}},
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Compact serialized issuer-signed JWT",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"disclosures": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Disclosures of the selective claims",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"sd_jwt": schema.StringAttribute{
+				MarkdownDescription: "SD-JWT, the issuer-signed JWT followed by every disclosure",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		}),
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkSdJwtSignDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkSdJwtSignDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkSdJwtSignDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jwkStr, err := d.provider.base64urlJwk(data.Jwk.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "base64urlJwk", fmt.Sprintf("Can't apply base64url handling : %s", err))
+		return
+	}
+	if err := d.provider.checkConformanceJwk(jwkStr); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "checkConformanceJwk", fmt.Sprintf("JWK rejected by the strict conformance : %s", err))
+		return
+	}
+	key, err := signingKey(jwkStr, data.Algorithm.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "signingKey", withJwkHint(fmt.Sprintf("Can't load signing key : %s", err), jwkStr, false))
+		return
+	}
+	if err := d.provider.checkSigningKey(key); err != nil {
+		resp.Diagnostics.AddError("checkSigningKey", fmt.Sprintf("Signing key rejected by the provider policy : %s", err))
+		return
+	}
+	d.provider.checkWeakSigningKey(&resp.Diagnostics, path.Root("jwk"), key)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	headers := map[string]string{}
+	if !data.Headers.IsNull() {
+		resp.Diagnostics.Append(data.Headers.ElementsAs(ctx, &headers, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	claims, err := data.claims(time.Now())
+	if err != nil {
+		resp.Diagnostics.AddError("claims", fmt.Sprintf("Can't build claims : %s", err))
+		return
+	}
+
+	var selectiveClaims map[string]any
+	decoder := json.NewDecoder(bytes.NewReader([]byte(data.SelectiveClaims.ValueString())))
+	decoder.UseNumber()
+	if err := decoder.Decode(&selectiveClaims); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("selective_claims"), "Decode", fmt.Sprintf("Can't decode selective_claims : %s", err))
+		return
+	}
+
+	var disclosures, digests []string
+	for name, value := range selectiveClaims {
+		if _, ok := claims[name]; ok || name == "_sd" || name == "_sd_alg" || name == "cnf" {
+			resp.Diagnostics.AddAttributeError(path.Root("selective_claims"), "selective_claims", fmt.Sprintf("Claim %s can't be selectively disclosed, it is already set", name))
+			return
+		}
+
+		disclosure, err := sdJwtDisclosure(name, value)
+		if err != nil {
+			resp.Diagnostics.AddError("sdJwtDisclosure", fmt.Sprintf("Can't create disclosure of %s : %s", name, err))
+			return
+		}
+		sum := sha256.Sum256([]byte(disclosure))
+		disclosures = append(disclosures, disclosure)
+		digests = append(digests, base64.RawURLEncoding.EncodeToString(sum[:]))
+	}
+	// Sorted digests don't reveal the original order of the claims.
+	sort.Strings(digests)
+	sort.Strings(disclosures)
+
+	claims["_sd"] = digests
+	claims["_sd_alg"] = "sha-256"
+	if !data.HolderJwk.IsNull() {
+		holderJwk, err := jwkutil.ParseJwk(data.HolderJwk.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("holder_jwk"), "ParseJwk", withJwkHint(fmt.Sprintf("Can't unmarshal holder_jwk : %s", err), data.HolderJwk.ValueString(), false))
+			return
+		}
+		claims["cnf"] = map[string]any{"jwk": jwkutil.PublicKey(holderJwk)}
+	}
+
+	signer, err := newJwtSigner(key, headers)
+	if err != nil {
+		resp.Diagnostics.AddError("NewSigner", fmt.Sprintf("Can't create signer : %s", err))
+		return
+	}
+
+	token, err := signJwt(signer, claims)
+	if err != nil {
+		resp.Diagnostics.AddError("signJwt", fmt.Sprintf("Can't sign token : %s", err))
+		return
+	}
+
+	sdJwt := token + "~"
+	for _, disclosure := range disclosures {
+		sdJwt += disclosure + "~"
+	}
+
+	data.Disclosures, _ = types.ListValueFrom(ctx, types.StringType, disclosures)
+	data.Id = types.StringValue(sha256Hex([]byte(sdJwt)))
+	data.SdJwt = types.StringValue(sdJwt)
+	data.Token = types.StringValue(token)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// sdJwtDisclosure returns the base64url encoded disclosure of an object
+// property, the JSON array of a random salt, its name and its value.
+func sdJwtDisclosure(name string, value any) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	disclosure, err := json.Marshal([]any{base64.RawURLEncoding.EncodeToString(salt), name, value})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(disclosure), nil
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ datasource.DataSource = &JwkSecurityStrengthDataSource{}
+
+type JwkSecurityStrengthDataSource struct{}
+
+type JwkSecurityStrengthDataSourceModel struct {
+	Id              types.String   `tfsdk:"id"`
+	Jwk             types.String   `tfsdk:"jwk"`
+	Jwks            types.List     `tfsdk:"jwks"`
+	Keys            types.List     `tfsdk:"keys"`
+	MinimumStrength types.Int64    `tfsdk:"minimum_strength"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+// keyStrengthAttrTypes are the attributes classifying a single key.
+var keyStrengthAttrTypes = map[string]attr.Type{
+	"kid":      types.StringType,
+	"kty":      types.StringType,
+	"status":   types.StringType,
+	"strength": types.Int64Type,
+}
+
+func NewJwkSecurityStrengthDataSource() datasource.DataSource {
+	return &JwkSecurityStrengthDataSource{}
+}
+
+func (d *JwkSecurityStrengthDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_security_strength"
+}
+
+func (d *JwkSecurityStrengthDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to classify keys into the security strength levels of NIST SP 800-57, such as in a precondition requiring a `minimum_strength` of at least 128 bits",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"jwk": schema.StringAttribute{
+				MarkdownDescription: "JWK or JWKS to classify, conflicts with `jwks`",
+				Optional:            true,
+				Sensitive:           true,
+				Validators:          []validator.String{jwkValidator{jwks: true}},
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKs to classify, conflicts with `jwk`",
+				Optional:            true,
+				Sensitive:           true,
+				Validators:          []validator.List{jwkListValidator{}},
+			},
+			"keys": schema.ListNestedAttribute{
+				MarkdownDescription: "Classification of every key",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"kid": schema.StringAttribute{
+							MarkdownDescription: "Key ID, null when not set",
+							Computed:            true,
+						},
+						"kty": schema.StringAttribute{
+							MarkdownDescription: "Key type",
+							Computed:            true,
+						},
+						"strength": schema.Int64Attribute{
+							MarkdownDescription: "Security strength level of the key in bits: `256`, `192`, `128`, `112`, `80` or `0` below",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "NIST SP 800-131A status of the strength level for protecting new data: `acceptable`, `acceptable_until_2030` or `disallowed`",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"minimum_strength": schema.Int64Attribute{
+				MarkdownDescription: "Lowest security strength level of the keys",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkSecurityStrengthDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+}
+
+func (d *JwkSecurityStrengthDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkSecurityStrengthDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, err := keySet(data.Jwk, data.Jwks)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(keySetPath(data.Jwk), "keySet", withJwkHint(fmt.Sprintf("Can't read keys : %s", err), data.Jwk.ValueString(), true))
+		return
+	}
+
+	var keysAttr []attr.Value
+	minimumStrength := strengthLevels[0]
+	for i, key := range keys {
+		jwk, err := jwkutil.ParseJwk(string(key))
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(keySetIndexPath(data.Jwk, i), "ParseJwk", fmt.Sprintf("Can't unmarshal JWK : %s", err))
+			return
+		}
+		var members struct {
+			Kty string `json:"kty"`
+		}
+		if err := json.Unmarshal(key, &members); err != nil {
+			resp.Diagnostics.AddAttributeError(keySetIndexPath(data.Jwk, i), "Unmarshal", fmt.Sprintf("Can't unmarshal JWK : %s", err))
+			return
+		}
+		_, strength, ok := keyStrength(jwk.Key)
+		if !ok {
+			resp.Diagnostics.AddAttributeError(keySetIndexPath(data.Jwk, i), "keyStrength", fmt.Sprintf("Can't estimate the strength of %T keys", jwk.Key))
+			return
+		}
+
+		level := strengthLevel(strength)
+		minimumStrength = min(minimumStrength, level)
+		value, _ := types.ObjectValue(keyStrengthAttrTypes, map[string]attr.Value{
+			"kid":      optionalString(jwk.KeyID),
+			"kty":      types.StringValue(members.Kty),
+			"status":   types.StringValue(strengthStatus(level)),
+			"strength": types.Int64Value(int64(level)),
+		})
+		keysAttr = append(keysAttr, value)
+	}
+	if len(keys) == 0 {
+		minimumStrength = 0
+	}
+
+	jwksData, err := json.Marshal(JwksResp{Keys: keys})
+	if err != nil {
+		resp.Diagnostics.AddError("Marshal", fmt.Sprintf("Can't marshal JwksResp : %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(sha256Hex(jwksData))
+	data.Keys, _ = types.ListValue(types.ObjectType{AttrTypes: keyStrengthAttrTypes}, keysAttr)
+	data.MinimumStrength = types.Int64Value(int64(minimumStrength))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
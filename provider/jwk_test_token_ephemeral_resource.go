@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/ephemeral/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const defaultTestTokenExpiresIn = 5 * time.Minute
+
+var _ ephemeral.EphemeralResource = &JwkTestTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &JwkTestTokenEphemeralResource{}
+
+type JwkTestTokenEphemeralResource struct {
+	provider *JwkProviderData
+}
+
+type JwkTestTokenEphemeralResourceModel struct {
+	JwtClaimsModel
+	Algorithm types.String   `tfsdk:"algorithm"`
+	ExpiresAt types.String   `tfsdk:"expires_at"`
+	Headers   types.Map      `tfsdk:"headers"`
+	Jwk       types.String   `tfsdk:"jwk"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+	Token     types.String   `tfsdk:"token"`
+}
+
+func NewJwkTestTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &JwkTestTokenEphemeralResource{}
+}
+
+func (r *JwkTestTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_test_token"
+}
+
+func (r *JwkTestTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This ephemeral resource can be used to mint a short-lived JWT signed with a private JWK, to test the relying parties trusting the key after apply. The token is never stored in the plan or the state",
+
+		Attributes: map[string]schema.Attribute{
+			"jwk": schema.StringAttribute{
+				MarkdownDescription: "Private JWK signing the token",
+				Required:            true,
+				Sensitive:           true,
+				Validators:          []validator.String{jwkValidator{private: true}},
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "Signature algorithm, defaults to the `alg` of the JWK or to the usual algorithm of its key type",
+				Optional:            true,
+			},
+			"headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Extra protected headers",
+				Optional:            true,
+			},
+			"claims": schema.StringAttribute{
+				MarkdownDescription: "JSON encoded claims, the other claim attributes take precedence",
+				Optional:            true,
+			},
+			"issuer": schema.StringAttribute{
+				MarkdownDescription: "`iss` claim",
+				Optional:            true,
+			},
+			"subject": schema.StringAttribute{
+				MarkdownDescription: "`sub` claim",
+				Optional:            true,
+			},
+			"audience": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "`aud` claim",
+				Optional:            true,
+			},
+			"expires_in": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Duration after which the token expires, sets the `exp` claim (default: `%s`)", defaultTestTokenExpiresIn),
+				Optional:            true,
+			},
+			"not_before": schema.StringAttribute{
+				MarkdownDescription: "Duration, possibly negative, after which the token becomes valid, sets the `nbf` claim",
+				Optional:            true,
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "`exp` claim in RFC 3339 format",
+				Computed:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Compact serialized JWT",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (r *JwkTestTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	r.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *JwkTestTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data JwkTestTokenEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := openContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jwkStr, err := r.provider.base64urlJwk(data.Jwk.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "base64urlJwk", fmt.Sprintf("Can't apply base64url handling : %s", err))
+		return
+	}
+	if err := r.provider.checkConformanceJwk(jwkStr); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "checkConformanceJwk", fmt.Sprintf("JWK rejected by the strict conformance : %s", err))
+		return
+	}
+	key, err := signingKey(jwkStr, data.Algorithm.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "signingKey", withJwkHint(fmt.Sprintf("Can't load signing key : %s", err), jwkStr, false))
+		return
+	}
+	if err := r.provider.checkSigningKey(key); err != nil {
+		resp.Diagnostics.AddError("checkSigningKey", fmt.Sprintf("Signing key rejected by the provider policy : %s", err))
+		return
+	}
+	r.provider.checkWeakSigningKey(&resp.Diagnostics, path.Root("jwk"), key)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	headers := map[string]string{}
+	if !data.Headers.IsNull() {
+		resp.Diagnostics.Append(data.Headers.ElementsAs(ctx, &headers, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	expiresIn, err := parseDuration(data.ExpiresIn, defaultTestTokenExpiresIn)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("expires_in"), "parseDuration", fmt.Sprintf("Invalid expires_in : %s", err))
+		return
+	}
+
+	now := time.Now()
+	claimsModel := data.JwtClaimsModel
+	claimsModel.ExpiresIn = types.StringValue(expiresIn.String())
+	claims, err := claimsModel.claims(now)
+	if err != nil {
+		resp.Diagnostics.AddError("claims", fmt.Sprintf("Can't build claims : %s", err))
+		return
+	}
+
+	signer, err := newJwtSigner(key, headers)
+	if err != nil {
+		resp.Diagnostics.AddError("NewSigner", fmt.Sprintf("Can't create signer : %s", err))
+		return
+	}
+
+	token, err := signJwt(signer, claims)
+	if err != nil {
+		resp.Diagnostics.AddError("signJwt", fmt.Sprintf("Can't sign token : %s", err))
+		return
+	}
+
+	data.ExpiresAt = types.StringValue(now.Add(expiresIn).UTC().Format(time.RFC3339))
+	data.Token = types.StringValue(token)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"crypto"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ function.Function = &JwkThumbprintFunction{}
+
+// thumbprintHashes are the hash functions the thumbprint function accepts.
+var thumbprintHashes = map[string]crypto.Hash{
+	"SHA-1":   crypto.SHA1,
+	"SHA-256": crypto.SHA256,
+	"SHA-384": crypto.SHA384,
+	"SHA-512": crypto.SHA512,
+}
+
+type JwkThumbprintFunction struct{}
+
+func NewJwkThumbprintFunction() function.Function {
+	return &JwkThumbprintFunction{}
+}
+
+func (f *JwkThumbprintFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "thumbprint"
+}
+
+func (f *JwkThumbprintFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "RFC 7638 thumbprint of a JWK",
+		MarkdownDescription: "Returns the base64url encoded RFC 7638 thumbprint of a JWK, computed with the hash function `alg`",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "jwk",
+				MarkdownDescription: "JWK",
+			},
+			function.StringParameter{
+				Name:                "alg",
+				MarkdownDescription: "Hash function, `SHA-1`, `SHA-256`, `SHA-384` or `SHA-512`",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *JwkThumbprintFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var jwkStr, alg string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &jwkStr, &alg))
+	if resp.Error != nil {
+		return
+	}
+
+	jwk, err := jwkutil.ParseJwk(jwkStr)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, withJwkHint(fmt.Sprintf("Can't unmarshal JWK : %s", err), jwkStr, false))
+		return
+	}
+
+	hash, ok := thumbprintHashes[alg]
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Unsupported hash function %q, expected SHA-1, SHA-256, SHA-384 or SHA-512", alg))
+		return
+	}
+
+	thumbprint, err := jwkutil.Thumbprint(jwk, hash)
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Can't compute thumbprint : %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, base64.RawURLEncoding.EncodeToString(thumbprint)))
+}
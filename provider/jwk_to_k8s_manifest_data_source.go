@@ -0,0 +1,280 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ datasource.DataSource = &JwkToK8sManifestDataSource{}
+
+type JwkToK8sManifestDataSource struct {
+	provider *JwkProviderData
+}
+
+type JwkToK8sManifestDataSourceModel struct {
+	Id           types.String   `tfsdk:"id"`
+	Jwk          types.String   `tfsdk:"jwk"`
+	Jwks         types.List     `tfsdk:"jwks"`
+	Key          types.String   `tfsdk:"key"`
+	Kind         types.String   `tfsdk:"kind"`
+	Labels       types.Map      `tfsdk:"labels"`
+	ManifestJson types.String   `tfsdk:"manifest_json"`
+	ManifestYaml types.String   `tfsdk:"manifest_yaml"`
+	Name         types.String   `tfsdk:"name"`
+	Namespace    types.String   `tfsdk:"namespace"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+type K8sManifest struct {
+	ApiVersion string              `json:"apiVersion"`
+	Kind       string              `json:"kind"`
+	Metadata   K8sManifestMetadata `json:"metadata"`
+	Type       string              `json:"type,omitempty"`
+	Data       map[string]string   `json:"data"`
+}
+
+type K8sManifestMetadata struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+func NewJwkToK8sManifestDataSource() datasource.DataSource {
+	return &JwkToK8sManifestDataSource{}
+}
+
+func (d *JwkToK8sManifestDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_to_k8s_manifest"
+}
+
+func (d *JwkToK8sManifestDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to generate the manifest of a K8S Secret or ConfigMap holding a JWK or a JWKS",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"jwk": schema.StringAttribute{
+				MarkdownDescription: "JWK or JWKS to store, conflicts with `jwks`",
+				Optional:            true,
+				Sensitive:           true,
+				Validators:          []validator.String{jwkValidator{jwks: true}},
+			},
+			"jwks": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of JWKs to store as a JWKS, conflicts with `jwk`",
+				Optional:            true,
+				Sensitive:           true,
+				Validators:          []validator.List{jwkListValidator{}},
+			},
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "Kind of the object, `Secret` or `ConfigMap`, defaults to `Secret` when a private key is stored and to `ConfigMap` otherwise",
+				Optional:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the object",
+				Required:            true,
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "Namespace of the object",
+				Optional:            true,
+			},
+			"labels": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Labels of the object",
+				Optional:            true,
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "Key of the object data holding the keys (default: `jwks.json`)",
+				Optional:            true,
+			},
+			"manifest_json": schema.StringAttribute{
+				MarkdownDescription: "Manifest in JSON format",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"manifest_yaml": schema.StringAttribute{
+				MarkdownDescription: "Manifest in YAML format",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkToK8sManifestDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *JwkToK8sManifestDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkToK8sManifestDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var keys []json.RawMessage
+	var content []byte
+	switch {
+	case !data.Jwk.IsNull() && !data.Jwks.IsNull():
+		resp.Diagnostics.AddAttributeError(path.Root("jwks"), "jwks", "Only one of jwk and jwks can be set")
+		return
+	case !data.Jwk.IsNull():
+		var err error
+		content = []byte(data.Jwk.ValueString())
+		keys, err = jwkutil.ParseJwks(content)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("jwk"), "ParseJwks", withJwkHint(fmt.Sprintf("Can't parse JWK content : %s", err), data.Jwk.ValueString(), true))
+			return
+		}
+	case !data.Jwks.IsNull():
+		var jwks []string
+		resp.Diagnostics.Append(data.Jwks.ElementsAs(ctx, &jwks, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, jwk := range jwks {
+			keys = append(keys, json.RawMessage(jwk))
+		}
+
+		var err error
+		content, err = json.Marshal(JwksResp{Keys: keys})
+		if err != nil {
+			resp.Diagnostics.AddError("Marshal", fmt.Sprintf("Can't marshal JwksResp : %s", err))
+			return
+		}
+	default:
+		resp.Diagnostics.AddAttributeError(path.Root("jwks"), "jwks", "One of jwk and jwks must be set")
+		return
+	}
+	if err := d.provider.checkOutputKeys(keys); err != nil {
+		resp.Diagnostics.AddAttributeError(keySetPath(data.Jwk), "checkOutputKeys", fmt.Sprintf("Keys rejected by the provider policy : %s", err))
+		return
+	}
+
+	kind := data.Kind.ValueString()
+	if kind == "" {
+		kind = "ConfigMap"
+		for i, key := range keys {
+			var jwk jose.JSONWebKey
+			if err := jwk.UnmarshalJSON(key); err != nil {
+				keyPath := path.Root("jwk")
+				if !data.Jwks.IsNull() {
+					keyPath = path.Root("jwks").AtListIndex(i)
+				}
+				resp.Diagnostics.AddAttributeError(keyPath, "UnmarshalJSON", withJwkHint(fmt.Sprintf("Can't unmarshal JWK #%d : %s", i, err), string(key), false))
+				return
+			}
+			if !jwk.IsPublic() {
+				kind = "Secret"
+			}
+		}
+	}
+
+	key := data.Key.ValueString()
+	if key == "" {
+		key = "jwks.json"
+	}
+
+	manifest := K8sManifest{
+		ApiVersion: "v1",
+		Kind:       kind,
+		Metadata: K8sManifestMetadata{
+			Name:      data.Name.ValueString(),
+			Namespace: data.Namespace.ValueString(),
+		},
+	}
+	switch kind {
+	case "Secret":
+		manifest.Type = "Opaque"
+		manifest.Data = map[string]string{key: base64.StdEncoding.EncodeToString(content)}
+	case "ConfigMap":
+		manifest.Data = map[string]string{key: string(content)}
+	default:
+		resp.Diagnostics.AddAttributeError(path.Root("kind"), "kind", fmt.Sprintf("Unsupported kind %q", kind))
+		return
+	}
+	if !data.Labels.IsNull() {
+		resp.Diagnostics.Append(data.Labels.ElementsAs(ctx, &manifest.Metadata.Labels, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	manifestJson, err := json.Marshal(manifest)
+	if err != nil {
+		resp.Diagnostics.AddError("Marshal", fmt.Sprintf("Can't marshal K8sManifest : %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(kind + "/" + manifest.Metadata.Name)
+	data.ManifestJson = types.StringValue(string(manifestJson))
+	data.ManifestYaml = types.StringValue(manifest.yaml())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// yaml renders the manifest in YAML, quoting every string as JSON does.
+func (m K8sManifest) yaml() string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "apiVersion: %s\n", quoteYaml(m.ApiVersion))
+	fmt.Fprintf(&out, "kind: %s\n", quoteYaml(m.Kind))
+	out.WriteString("metadata:\n")
+	fmt.Fprintf(&out, "  name: %s\n", quoteYaml(m.Metadata.Name))
+	if m.Metadata.Namespace != "" {
+		fmt.Fprintf(&out, "  namespace: %s\n", quoteYaml(m.Metadata.Namespace))
+	}
+	if len(m.Metadata.Labels) > 0 {
+		out.WriteString("  labels:\n")
+		writeYamlMap(&out, "    ", m.Metadata.Labels)
+	}
+	if m.Type != "" {
+		fmt.Fprintf(&out, "type: %s\n", quoteYaml(m.Type))
+	}
+	out.WriteString("data:\n")
+	writeYamlMap(&out, "  ", m.Data)
+	return out.String()
+}
+
+func writeYamlMap(out *strings.Builder, indent string, values map[string]string) {
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Fprintf(out, "%s%s: %s\n", indent, quoteYaml(key), quoteYaml(values[key]))
+	}
+}
+
+// quoteYaml quotes s as a JSON string, which is a valid YAML double-quoted
+// scalar.
+func quoteYaml(s string) string {
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
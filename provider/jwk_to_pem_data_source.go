@@ -8,20 +8,28 @@ import (
 	"fmt"
 	"strings"
 
-	jose "github.com/go-jose/go-jose/v3"
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ datasource.DataSource = &JwkToPemDataSource{}
 
-type JwkToPemDataSource struct{}
+type JwkToPemDataSource struct {
+	provider *JwkProviderData
+}
 
 type JwkToPemDataSourceModel struct {
-	Id  types.String `tfsdk:"id"`
-	Jwk types.String `tfsdk:"jwk"`
-	Pem types.String `tfsdk:"pem"`
+	Id              types.String   `tfsdk:"id"`
+	Jwk             types.String   `tfsdk:"jwk"`
+	Pem             types.String   `tfsdk:"pem"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+	TrailingNewline types.Bool     `tfsdk:"trailing_newline"`
 }
 
 func NewJwkToPemDataSource() datasource.DataSource {
@@ -38,22 +46,33 @@ func (d *JwkToPemDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				MarkdownDescription: "ID",
+				MarkdownDescription: "ID, the `kid` of the JWK or its RFC 7638 thumbprint when it has none",
 				Computed:            true,
 			},
 			"jwk": schema.StringAttribute{
 				MarkdownDescription: "JWK",
 				Required:            true,
+				Sensitive:           true,
+				Validators:          []validator.String{jwkValidator{}},
+			},
+			"trailing_newline": schema.BoolAttribute{
+				MarkdownDescription: "Keep the trailing newline of the PEM encoding, like the SDK based versions of this data source did, to avoid spurious diffs when upgrading",
+				Optional:            true,
 			},
 			"pem": schema.StringAttribute{
 				MarkdownDescription: "PEM",
 				Computed:            true,
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
 	}
 }
 
 func (d *JwkToPemDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provider = configuredProviderData(req.ProviderData, &resp.Diagnostics)
 }
 
 func (d *JwkToPemDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -61,37 +80,81 @@ func (d *JwkToPemDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	jwkStr := data.Jwk.ValueString()
+	jwkStr, err := d.provider.base64urlJwk(data.Jwk.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "base64urlJwk", fmt.Sprintf("Can't apply base64url handling : %s", err))
+		return
+	}
+	if err := d.provider.checkConformanceJwk(jwkStr); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "checkConformanceJwk", fmt.Sprintf("JWK rejected by the strict conformance : %s", err))
+		return
+	}
 
 	var jwk jose.JSONWebKey
-	err := jwk.UnmarshalJSON([]byte(jwkStr))
+	err = jwk.UnmarshalJSON([]byte(jwkStr))
 	if err != nil {
-		resp.Diagnostics.AddError("UnmarshalJSON", fmt.Sprintf("Can't unmarshal JWK : %s", err))
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "UnmarshalJSON", withJwkHint(fmt.Sprintf("Can't unmarshal JWK : %s", err), jwkStr, false))
+		return
+	}
+	if err := d.provider.checkKey(jwk.Key); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("jwk"), "checkKey", fmt.Sprintf("Key rejected by the provider policy : %s", err))
+		return
+	}
+	d.provider.checkWeakKey(&resp.Diagnostics, path.Root("jwk"), jwk, "")
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	pubData, err := x509.MarshalPKIXPublicKey(jwk.Key)
+	pemStr, err := publicKeyPem(jwk.Key)
 	if err != nil {
-		resp.Diagnostics.AddError("MarshalPKIXPublicKey", fmt.Sprintf("Fail to marshal key: %s", err))
+		resp.Diagnostics.AddError("publicKeyPem", fmt.Sprintf("Fail to convert key to PEM : %s", err))
 		return
 	}
 
-	var pemData bytes.Buffer
-	err = pem.Encode(&pemData, &pem.Block{
-		Type:  "PUBLIC KEY",
-		Bytes: pubData,
+	tflog.Debug(withRedaction(ctx), "Converted JWK to PEM", map[string]any{
+		"kid":     jwk.KeyID,
+		"private": !jwk.IsPublic(),
 	})
+
+	// Keys without kid get a stable ID instead of an empty one.
+	id, err := keyIdOrThumbprint(jwk)
 	if err != nil {
-		resp.Diagnostics.AddError("Encode", fmt.Sprintf("Fail to encode PEM key: %s", err))
+		resp.Diagnostics.AddError("Thumbprint", fmt.Sprintf("Can't compute thumbprint : %s", err))
 		return
 	}
 
-	data.Id = types.StringValue(jwk.KeyID)
-	data.Pem = types.StringValue(strings.TrimSpace(pemData.String()))
+	if data.TrailingNewline.ValueBool() {
+		pemStr += "\n"
+	}
+
+	data.Id = types.StringValue(id)
+	data.Pem = types.StringValue(pemStr)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// publicKeyPem returns key PEM encoded as a PKIX public key, without trailing
+// newline.
+func publicKeyPem(key any) (string, error) {
+	pubData, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", fmt.Errorf("can't marshal key: %s", err)
+	}
+
+	var pemData bytes.Buffer
+	err = pem.Encode(&pemData, &pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: pubData,
+	})
+	if err != nil {
+		return "", fmt.Errorf("can't encode PEM key: %s", err)
+	}
+	return strings.TrimSpace(pemData.String()), nil
+}
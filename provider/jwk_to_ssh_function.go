@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+	"golang.org/x/crypto/ssh"
+)
+
+var _ function.Function = &JwkToSshFunction{}
+
+type JwkToSshFunction struct{}
+
+func NewJwkToSshFunction() function.Function {
+	return &JwkToSshFunction{}
+}
+
+func (f *JwkToSshFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "to_ssh"
+}
+
+func (f *JwkToSshFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Convert a JWK to an OpenSSH public key",
+		MarkdownDescription: "Returns the public key of an RSA, EC or OKP JWK as an OpenSSH `authorized_keys` line, without comment. Private JWKs are converted to their public form",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "jwk",
+				MarkdownDescription: "JWK",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *JwkToSshFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var jwkStr string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &jwkStr))
+	if resp.Error != nil {
+		return
+	}
+
+	jwk, err := jwkutil.ParseJwk(jwkStr)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, withJwkHint(fmt.Sprintf("Can't unmarshal JWK : %s", err), jwkStr, false))
+		return
+	}
+	if _, ok := jwk.Key.([]byte); ok {
+		resp.Error = function.NewArgumentFuncError(0, "Symmetric keys have no OpenSSH form")
+		return
+	}
+
+	publicKey, err := ssh.NewPublicKey(jwkutil.PublicKey(jwk).Key)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Can't convert JWK to an OpenSSH public key : %s", err))
+		return
+	}
+
+	authorizedKey := strings.TrimSuffix(string(ssh.MarshalAuthorizedKey(publicKey)), "\n")
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, authorizedKey))
+}
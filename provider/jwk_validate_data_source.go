@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ datasource.DataSource = &JwkValidateDataSource{}
+
+type JwkValidateDataSource struct{}
+
+type JwkValidateDataSourceModel struct {
+	CaCertificate          types.String   `tfsdk:"ca_certificate"`
+	CertificateExpired     types.Bool     `tfsdk:"certificate_expired"`
+	CompromisedThumbprints types.List     `tfsdk:"compromised_thumbprints"`
+	Errors                 types.List     `tfsdk:"errors"`
+	ExpiresInDays          types.Int64    `tfsdk:"expires_in_days"`
+	FailWithinDays         types.Int64    `tfsdk:"fail_within_days"`
+	Fips                   types.Bool     `tfsdk:"fips"`
+	Id                     types.String   `tfsdk:"id"`
+	Jwk                    types.String   `tfsdk:"jwk"`
+	NotAfter               types.String   `tfsdk:"not_after"`
+	NotBefore              types.String   `tfsdk:"not_before"`
+	Size                   types.Int64    `tfsdk:"size"`
+	Strength               types.Int64    `tfsdk:"strength"`
+	Timeouts               timeouts.Value `tfsdk:"timeouts"`
+	Valid                  types.Bool     `tfsdk:"valid"`
+	Warnings               types.List     `tfsdk:"warnings"`
+}
+
+func NewJwkValidateDataSource() datasource.DataSource {
+	return &JwkValidateDataSource{}
+}
+
+func (d *JwkValidateDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_validate"
+}
+
+func (d *JwkValidateDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to validate a JWK: its structure, the members required by its key type, its base64url members and the consistency of its key material. It doesn't fail on invalid JWKs, use `valid` in preconditions",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+			},
+			"jwk": schema.StringAttribute{
+				MarkdownDescription: "JWK to validate",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"fips": schema.BoolAttribute{
+				MarkdownDescription: "Also report the key sizes, curves and algorithms not approved by FIPS 140-3 and FIPS 186-5 as errors, regardless of the provider `fips_mode`",
+				Optional:            true,
+			},
+			"ca_certificate": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificates the `x5c` chain of the JWK must lead to. When set, the data source fails on JWKs without chain and on chains that are expired, badly signed or that don't lead to one of them",
+				Optional:            true,
+				Validators:          []validator.String{pemValidator{}},
+			},
+			"compromised_thumbprints": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "RFC 7638 SHA-256 thumbprints of keys known to be compromised, reported as errors. The provider ships no list of compromised keys: the Debian OpenSSL weak keys (CVE-2008-0166) are only detected when their thumbprints are listed here. The modulus of RSA keys is always checked for the ROCA fingerprint, small factors and close primes",
+				Optional:            true,
+			},
+			"fail_within_days": schema.Int64Attribute{
+				MarkdownDescription: "Fail when the `x5c` certificate of the JWK is expired or expires within this number of days",
+				Optional:            true,
+			},
+			"valid": schema.BoolAttribute{
+				MarkdownDescription: "Whether the JWK has no errors",
+				Computed:            true,
+			},
+			"errors": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Problems making the JWK unusable",
+				Computed:            true,
+			},
+			"warnings": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Problems of a usable JWK, such as weak key material or a missing `kid`",
+				Computed:            true,
+			},
+			"size": schema.Int64Attribute{
+				MarkdownDescription: "Size of the key in bits: modulus length for RSA keys, curve size for EC and OKP keys, null when it can't be parsed",
+				Computed:            true,
+			},
+			"strength": schema.Int64Attribute{
+				MarkdownDescription: "Estimated security strength of the key in bits, per NIST SP 800-57, null when it can't be parsed",
+				Computed:            true,
+			},
+			"not_before": schema.StringAttribute{
+				MarkdownDescription: "RFC 3339 start of validity of the first certificate of the `x5c` chain of the JWK, null without chain",
+				Computed:            true,
+			},
+			"not_after": schema.StringAttribute{
+				MarkdownDescription: "RFC 3339 end of validity of the first certificate of the `x5c` chain of the JWK, null without chain",
+				Computed:            true,
+			},
+			"certificate_expired": schema.BoolAttribute{
+				MarkdownDescription: "Whether the first certificate of the `x5c` chain of the JWK is expired, null without chain",
+				Computed:            true,
+			},
+			"expires_in_days": schema.Int64Attribute{
+				MarkdownDescription: "Number of whole days before the first certificate of the `x5c` chain of the JWK expires, negative once expired, null without chain",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *JwkValidateDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+}
+
+func (d *JwkValidateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JwkValidateDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	ctx, cancel := readContext(ctx, data.Timeouts, &resp.Diagnostics)
+	defer cancel()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	now := time.Now()
+	report := validateJwk(data.Jwk.ValueString(), data.Fips.ValueBool())
+	report.checkCertificateValidity(now)
+
+	if caCertificate := data.CaCertificate.ValueString(); caCertificate != "" {
+		caCertPool := x509.NewCertPool()
+		if ok := caCertPool.AppendCertsFromPEM([]byte(caCertificate)); !ok {
+			resp.Diagnostics.AddAttributeError(path.Root("ca_certificate"), "AppendCertsFromPEM", "Can't load CA certificate")
+			return
+		}
+		if err := report.verifyCertificateChain(caCertPool, now); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("jwk"), "verifyCertificateChain", fmt.Sprintf("Can't verify the x5c certificate chain : %s", err))
+			return
+		}
+	}
+
+	var compromisedThumbprints []string
+	resp.Diagnostics.Append(data.CompromisedThumbprints.ElementsAs(ctx, &compromisedThumbprints, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	report.checkCompromisedThumbprint(compromisedThumbprints)
+
+	data.NotBefore = types.StringNull()
+	data.NotAfter = types.StringNull()
+	data.CertificateExpired = types.BoolNull()
+	data.ExpiresInDays = types.Int64Null()
+	if len(report.certificates) > 0 {
+		cert := report.certificates[0]
+		expiresInDays := int64(math.Floor(cert.NotAfter.Sub(now).Hours() / 24))
+
+		data.NotBefore = types.StringValue(cert.NotBefore.UTC().Format(time.RFC3339))
+		data.NotAfter = types.StringValue(cert.NotAfter.UTC().Format(time.RFC3339))
+		data.CertificateExpired = types.BoolValue(now.After(cert.NotAfter))
+		data.ExpiresInDays = types.Int64Value(expiresInDays)
+
+		if !data.FailWithinDays.IsNull() && expiresInDays < data.FailWithinDays.ValueInt64() {
+			resp.Diagnostics.AddAttributeError(path.Root("jwk"), "fail_within_days", fmt.Sprintf("The x5c certificate expires on %s, within fail_within_days", data.NotAfter.ValueString()))
+			return
+		}
+	}
+
+	var diags diag.Diagnostics
+	data.Errors, diags = types.ListValueFrom(ctx, types.StringType, append([]string{}, report.errors...))
+	resp.Diagnostics.Append(diags...)
+	data.Warnings, diags = types.ListValueFrom(ctx, types.StringType, append([]string{}, report.warnings...))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Size, data.Strength = types.Int64Null(), types.Int64Null()
+	if jwk, err := jwkutil.ParseJwk(data.Jwk.ValueString()); err == nil {
+		if bits, strength, ok := keyStrength(jwk.Key); ok {
+			data.Size, data.Strength = types.Int64Value(int64(bits)), types.Int64Value(int64(strength))
+		}
+	}
+
+	data.Id = types.StringValue(sha256Hex([]byte(data.Jwk.ValueString())))
+	data.Valid = types.BoolValue(len(report.errors) == 0)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
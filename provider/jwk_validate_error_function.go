@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &JwkValidateErrorFunction{}
+
+type JwkValidateErrorFunction struct{}
+
+func NewJwkValidateErrorFunction() function.Function {
+	return &JwkValidateErrorFunction{}
+}
+
+func (f *JwkValidateErrorFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_error"
+}
+
+func (f *JwkValidateErrorFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Tell why a JWK is invalid",
+		MarkdownDescription: "Returns why a string isn't a JWK holding valid key material, empty when it is, for the error messages of variable validations and preconditions",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "jwk",
+				MarkdownDescription: "JWK",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *JwkValidateErrorFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var jwkStr string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &jwkStr))
+	if resp.Error != nil {
+		return
+	}
+
+	var reason string
+	if err := checkJwk(jwkStr, false); err != nil {
+		reason = withJwkHint(fmt.Sprintf("Can't parse JWK : %s", err), jwkStr, false)
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, reason))
+}
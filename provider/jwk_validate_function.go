@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &JwkValidateFunction{}
+
+type JwkValidateFunction struct{}
+
+func NewJwkValidateFunction() function.Function {
+	return &JwkValidateFunction{}
+}
+
+func (f *JwkValidateFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate"
+}
+
+func (f *JwkValidateFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Check a JWK is valid",
+		MarkdownDescription: "Returns whether a string is a JWK holding valid key material, for variable validations and preconditions. `validate_error` tells why it isn't",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "jwk",
+				MarkdownDescription: "JWK",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *JwkValidateFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var jwkStr string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &jwkStr))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, checkJwk(jwkStr, false) == nil))
+}
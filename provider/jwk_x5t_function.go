@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ function.Function = &JwkX5tFunction{}
+
+type JwkX5tFunction struct{}
+
+// x5tAttrTypes are the attributes returned by the x5t function.
+var x5tAttrTypes = map[string]attr.Type{
+	"x5t":      types.StringType,
+	"x5t_s256": types.StringType,
+}
+
+func NewJwkX5tFunction() function.Function {
+	return &JwkX5tFunction{}
+}
+
+func (f *JwkX5tFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "x5t"
+}
+
+func (f *JwkX5tFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Certificate thumbprints",
+		MarkdownDescription: "Returns the `x5t` (SHA-1) and `x5t#S256` (SHA-256) thumbprints of a PEM encoded certificate, or of the first certificate of the `x5c` chain of a JWK, as an object with `x5t` and `x5t_s256` attributes",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "certificate",
+				MarkdownDescription: "PEM encoded certificate or JWK with an `x5c` certificate chain",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: x5tAttrTypes,
+		},
+	}
+}
+
+func (f *JwkX5tFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var certificate string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &certificate))
+	if resp.Error != nil {
+		return
+	}
+
+	var der []byte
+	if jwkutil.IsPem([]byte(certificate)) {
+		block, _ := pem.Decode([]byte(certificate))
+		if block == nil || block.Type != "CERTIFICATE" {
+			resp.Error = function.NewArgumentFuncError(0, "Can't decode PEM : no CERTIFICATE block found")
+			return
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Can't parse certificate : %s", err))
+			return
+		}
+		der = cert.Raw
+	} else {
+		jwk, err := jwkutil.ParseJwk(certificate)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(0, withJwkHint(fmt.Sprintf("Can't unmarshal JWK : %s", err), certificate, false))
+			return
+		}
+		if len(jwk.Certificates) == 0 {
+			resp.Error = function.NewArgumentFuncError(0, "The JWK has no x5c certificate chain")
+			return
+		}
+		der = jwk.Certificates[0].Raw
+	}
+
+	sha1Sum := sha1.Sum(der)
+	sha256Sum := sha256.Sum256(der)
+	value, diags := types.ObjectValue(x5tAttrTypes, map[string]attr.Value{
+		"x5t":      types.StringValue(base64.RawURLEncoding.EncodeToString(sha1Sum[:])),
+		"x5t_s256": types.StringValue(base64.RawURLEncoding.EncodeToString(sha256Sum[:])),
+	})
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, value))
+}
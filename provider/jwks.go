@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+// keySet returns the keys of jwk, a JWK or a JWKS, or of jwks, a list of
+// JWKs. Exactly one of them must be set.
+func keySet(jwk types.String, jwks types.List) ([]json.RawMessage, error) {
+	switch {
+	case !jwk.IsNull() && !jwks.IsNull():
+		return nil, fmt.Errorf("only one of jwk and jwks can be set")
+	case !jwk.IsNull():
+		return jwkutil.ParseJwks([]byte(jwk.ValueString()))
+	case !jwks.IsNull():
+		var keys []json.RawMessage
+		for _, value := range jwks.Elements() {
+			keys = append(keys, json.RawMessage(value.(types.String).ValueString()))
+		}
+		return keys, nil
+	default:
+		return nil, fmt.Errorf("one of jwk and jwks must be set")
+	}
+}
+
+// jwksListValue returns the compacted JSON of every key as a list of strings.
+func jwksListValue(keys []json.RawMessage) (types.List, error) {
+	var jwksAttr []attr.Value
+	for _, jwkRaw := range keys {
+		jwk, err := json.Marshal(&jwkRaw)
+		if err != nil {
+			return types.ListNull(types.StringType), err
+		}
+		jwksAttr = append(jwksAttr, types.StringValue(string(jwk)))
+	}
+
+	list, _ := types.ListValue(types.StringType, jwksAttr)
+	return list, nil
+}
+
+// jwkKeyAttrTypes are the attributes describing a single key of a key set.
+var jwkKeyAttrTypes = map[string]attr.Type{
+	"alg":        types.StringType,
+	"crv":        types.StringType,
+	"jwk":        types.StringType,
+	"kid":        types.StringType,
+	"kty":        types.StringType,
+	"size":       types.Int64Type,
+	"strength":   types.Int64Type,
+	"thumbprint": types.StringType,
+	"use":        types.StringType,
+}
+
+// keysAttribute returns the computed keys attribute describing every key of
+// the jwks attribute, jwk being sensitive when the keys can be private.
+func keysAttribute(sensitive bool) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "Keys of `jwks`, with their main members",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"alg": schema.StringAttribute{
+					MarkdownDescription: "Algorithm of the key, null when not set",
+					Computed:            true,
+				},
+				"crv": schema.StringAttribute{
+					MarkdownDescription: "Curve of the key, null for RSA and symmetric keys",
+					Computed:            true,
+				},
+				"jwk": schema.StringAttribute{
+					MarkdownDescription: "JWK",
+					Computed:            true,
+					Sensitive:           sensitive,
+				},
+				"kid": schema.StringAttribute{
+					MarkdownDescription: "Key ID, null when not set",
+					Computed:            true,
+				},
+				"kty": schema.StringAttribute{
+					MarkdownDescription: "Key type",
+					Computed:            true,
+				},
+				"size": schema.Int64Attribute{
+					MarkdownDescription: "Size of the key in bits: modulus length for RSA keys, curve size for EC and OKP keys",
+					Computed:            true,
+				},
+				"strength": schema.Int64Attribute{
+					MarkdownDescription: "Estimated security strength of the key in bits, per NIST SP 800-57",
+					Computed:            true,
+				},
+				"thumbprint": schema.StringAttribute{
+					MarkdownDescription: "RFC 7638 SHA-256 thumbprint of the key",
+					Computed:            true,
+				},
+				"use": schema.StringAttribute{
+					MarkdownDescription: "Public key use, null when not set",
+					Computed:            true,
+				},
+			},
+		},
+	}
+}
+
+// keysListValue returns the description of every key as a list of objects.
+func keysListValue(keys []json.RawMessage) (types.List, error) {
+	elemType := types.ObjectType{AttrTypes: jwkKeyAttrTypes}
+
+	var keysAttr []attr.Value
+	for _, key := range keys {
+		var members struct {
+			Alg string `json:"alg"`
+			Crv string `json:"crv"`
+			Kid string `json:"kid"`
+			Kty string `json:"kty"`
+			Use string `json:"use"`
+		}
+		if err := json.Unmarshal(key, &members); err != nil {
+			return types.ListNull(elemType), err
+		}
+
+		thumbprint := types.StringNull()
+		size, strength := types.Int64Null(), types.Int64Null()
+		if jwk, err := jwkutil.ParseJwk(string(key)); err == nil {
+			if sum, err := jwkutil.Thumbprint(jwk, crypto.SHA256); err == nil {
+				thumbprint = types.StringValue(base64.RawURLEncoding.EncodeToString(sum))
+			}
+			if bits, bitsStrength, ok := keyStrength(jwk.Key); ok {
+				size, strength = types.Int64Value(int64(bits)), types.Int64Value(int64(bitsStrength))
+			}
+		}
+
+		compacted, err := json.Marshal(&key)
+		if err != nil {
+			return types.ListNull(elemType), err
+		}
+
+		value, _ := types.ObjectValue(jwkKeyAttrTypes, map[string]attr.Value{
+			"alg":        optionalString(members.Alg),
+			"crv":        optionalString(members.Crv),
+			"jwk":        types.StringValue(string(compacted)),
+			"kid":        optionalString(members.Kid),
+			"kty":        types.StringValue(members.Kty),
+			"size":       size,
+			"strength":   strength,
+			"thumbprint": thumbprint,
+			"use":        optionalString(members.Use),
+		})
+		keysAttr = append(keysAttr, value)
+	}
+
+	list, _ := types.ListValue(elemType, keysAttr)
+	return list, nil
+}
+
+// finishKeys applies the provider base64url, conformance and output policy to
+// the keys read by a data source and returns them, along with the values of
+// its jwks and keys attributes, reporting the weak and expiring ones. Errors
+// are added at attrPath, to the whole data source when empty.
+func (p *JwkProviderData) finishKeys(diags *diag.Diagnostics, attrPath path.Path, keys []json.RawMessage) ([]json.RawMessage, types.List, types.List) {
+	jwks, keysList := types.ListNull(types.StringType), types.ListNull(types.ObjectType{AttrTypes: jwkKeyAttrTypes})
+	addError := func(summary, detail string) {
+		if attrPath.Equal(path.Empty()) {
+			diags.AddError(summary, detail)
+		} else {
+			diags.AddAttributeError(attrPath, summary, detail)
+		}
+	}
+
+	keys, err := p.base64urlKeys(keys)
+	if err != nil {
+		addError("base64urlKeys", fmt.Sprintf("Can't apply base64url handling : %s", err))
+		return nil, jwks, keysList
+	}
+	if err := p.checkConformance(keys); err != nil {
+		addError("checkConformance", fmt.Sprintf("Keys rejected by the strict conformance : %s", err))
+		return nil, jwks, keysList
+	}
+	if err := p.checkOutputKeys(keys); err != nil {
+		addError("checkOutputKeys", fmt.Sprintf("Keys rejected by the provider policy : %s", err))
+		return nil, jwks, keysList
+	}
+
+	jwks, err = jwksListValue(keys)
+	if err != nil {
+		diags.AddError("Marshal", fmt.Sprintf("Can't marshal jwkRaw : %s", err))
+		return nil, jwks, keysList
+	}
+	keysList, err = keysListValue(keys)
+	if err != nil {
+		diags.AddError("keysListValue", fmt.Sprintf("Can't describe keys : %s", err))
+		return nil, jwks, keysList
+	}
+	p.checkWeakKeys(diags, types.StringNull(), keys)
+	p.checkExpiringKeys(diags, types.StringNull(), keys)
+	return keys, jwks, keysList
+}
+
+// keyIdOrThumbprint returns the kid of jwk, or its RFC 7638 SHA-256
+// thumbprint when it has none.
+func keyIdOrThumbprint(jwk jose.JSONWebKey) (string, error) {
+	if jwk.KeyID != "" {
+		return jwk.KeyID, nil
+	}
+	thumbprint, err := jwkutil.Thumbprint(jwk, crypto.SHA256)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
+}
+
+// optionalString returns value, null when empty.
+func optionalString(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// canonicalKey returns key as marshalled back by go-jose, which drops the
+// unknown members and orders the known ones.
+func canonicalKey(key json.RawMessage) (json.RawMessage, error) {
+	jwk, err := jwkutil.ParseJwk(string(key))
+	if err != nil {
+		return nil, err
+	}
+	return jwk.MarshalJSON()
+}
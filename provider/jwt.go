@@ -0,0 +1,332 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+// JwtClaimsModel holds the attributes used to build the claims of a JWT. It
+// is meant to be embedded in the data source model.
+type JwtClaimsModel struct {
+	Audience  types.List   `tfsdk:"audience"`
+	Claims    types.String `tfsdk:"claims"`
+	ExpiresIn types.String `tfsdk:"expires_in"`
+	Issuer    types.String `tfsdk:"issuer"`
+	NotBefore types.String `tfsdk:"not_before"`
+	Subject   types.String `tfsdk:"subject"`
+}
+
+// withJwtClaimsAttributes adds the JwtClaimsModel attributes to attrs.
+func withJwtClaimsAttributes(attrs map[string]schema.Attribute) map[string]schema.Attribute {
+	attrs["claims"] = schema.StringAttribute{
+		MarkdownDescription: "JSON encoded claims, the other claim attributes take precedence",
+		Optional:            true,
+	}
+	attrs["issuer"] = schema.StringAttribute{
+		MarkdownDescription: "`iss` claim",
+		Optional:            true,
+	}
+	attrs["subject"] = schema.StringAttribute{
+		MarkdownDescription: "`sub` claim",
+		Optional:            true,
+	}
+	attrs["audience"] = schema.ListAttribute{
+		ElementType:         types.StringType,
+		MarkdownDescription: "`aud` claim",
+		Optional:            true,
+	}
+	attrs["expires_in"] = schema.StringAttribute{
+		MarkdownDescription: "Duration after which the token expires, sets the `exp` claim",
+		Optional:            true,
+	}
+	attrs["not_before"] = schema.StringAttribute{
+		MarkdownDescription: "Duration, possibly negative, after which the token becomes valid, sets the `nbf` claim",
+		Optional:            true,
+	}
+	return attrs
+}
+
+// claims returns the claims of a JWT issued at now. `iat` is set unless the
+// claims attribute already holds it.
+func (m JwtClaimsModel) claims(now time.Time) (map[string]any, error) {
+	claims := map[string]any{}
+	if claimsJson := m.Claims.ValueString(); claimsJson != "" {
+		decoder := json.NewDecoder(bytes.NewReader([]byte(claimsJson)))
+		decoder.UseNumber()
+		if err := decoder.Decode(&claims); err != nil {
+			return nil, fmt.Errorf("can't decode claims: %s", err)
+		}
+	}
+
+	if _, ok := claims["iat"]; !ok {
+		claims["iat"] = now.Unix()
+	}
+	if issuer := m.Issuer.ValueString(); issuer != "" {
+		claims["iss"] = issuer
+	}
+	if subject := m.Subject.ValueString(); subject != "" {
+		claims["sub"] = subject
+	}
+	if !m.Audience.IsNull() {
+		var audience []string
+		for _, value := range m.Audience.Elements() {
+			audience = append(audience, value.(types.String).ValueString())
+		}
+		if len(audience) == 1 {
+			claims["aud"] = audience[0]
+		} else {
+			claims["aud"] = audience
+		}
+	}
+	if expiresIn := m.ExpiresIn.ValueString(); expiresIn != "" {
+		duration, err := time.ParseDuration(expiresIn)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expires_in: %s", err)
+		}
+		claims["exp"] = now.Add(duration).Unix()
+	}
+	if notBefore := m.NotBefore.ValueString(); notBefore != "" {
+		duration, err := time.ParseDuration(notBefore)
+		if err != nil {
+			return nil, fmt.Errorf("invalid not_before: %s", err)
+		}
+		claims["nbf"] = now.Add(duration).Unix()
+	}
+
+	return claims, nil
+}
+
+// JwtValidationModel holds the attributes used to validate a JWT. It is meant
+// to be embedded in the data source model.
+type JwtValidationModel struct {
+	Algorithms       types.List   `tfsdk:"algorithms"`
+	ExpectedAudience types.String `tfsdk:"expected_audience"`
+	ExpectedIssuer   types.String `tfsdk:"expected_issuer"`
+	Leeway           types.String `tfsdk:"leeway"`
+}
+
+// withJwtValidationAttributes adds the JwtValidationModel attributes to attrs.
+func withJwtValidationAttributes(attrs map[string]schema.Attribute) map[string]schema.Attribute {
+	attrs["algorithms"] = schema.ListAttribute{
+		ElementType:         types.StringType,
+		MarkdownDescription: "Accepted signature algorithms, the token is rejected before any verification when signed with another one, defaults to the `signature_algorithms` of the provider",
+		Optional:            true,
+	}
+	attrs["expected_issuer"] = schema.StringAttribute{
+		MarkdownDescription: "Fail if the `iss` claim doesn't match this",
+		Optional:            true,
+	}
+	attrs["expected_audience"] = schema.StringAttribute{
+		MarkdownDescription: "Fail if the `aud` claim doesn't contain this",
+		Optional:            true,
+	}
+	attrs["leeway"] = schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("Clock skew tolerated when checking the time claims (default: `%s`)", jwt.DefaultLeeway),
+		Optional:            true,
+	}
+	return attrs
+}
+
+// JwtClaimsOutputModel holds the claims of a verified JWT. It is meant to be
+// embedded in the data source model.
+type JwtClaimsOutputModel struct {
+	Claims    types.String `tfsdk:"claims"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+	Issuer    types.String `tfsdk:"issuer"`
+	KeyId     types.String `tfsdk:"key_id"`
+	Subject   types.String `tfsdk:"subject"`
+}
+
+// withJwtClaimsOutputAttributes adds the JwtClaimsOutputModel attributes to
+// attrs.
+func withJwtClaimsOutputAttributes(attrs map[string]schema.Attribute) map[string]schema.Attribute {
+	attrs["claims"] = schema.StringAttribute{
+		MarkdownDescription: "JSON encoded claims",
+		Computed:            true,
+	}
+	attrs["issuer"] = schema.StringAttribute{
+		MarkdownDescription: "`iss` claim",
+		Computed:            true,
+	}
+	attrs["subject"] = schema.StringAttribute{
+		MarkdownDescription: "`sub` claim",
+		Computed:            true,
+	}
+	attrs["expires_at"] = schema.StringAttribute{
+		MarkdownDescription: "`exp` claim in RFC 3339 format",
+		Computed:            true,
+	}
+	attrs["key_id"] = schema.StringAttribute{
+		MarkdownDescription: "Key ID of the JWK that verified the token",
+		Computed:            true,
+	}
+	return attrs
+}
+
+// verifyJwt verifies the signature of token with keys and validates its
+// claims. The algorithms accepted default to the ones of p.
+func (m JwtValidationModel) verifyJwt(p *JwkProviderData, token string, keys []json.RawMessage) (JwtClaimsOutputModel, error) {
+	var out JwtClaimsOutputModel
+
+	algorithms, err := p.signatureAlgorithms(m.Algorithms)
+	if err != nil {
+		return out, fmt.Errorf("invalid algorithms: %s", err)
+	}
+
+	jws, err := jose.ParseSigned(token, algorithms)
+	if err != nil {
+		return out, fmt.Errorf("can't parse token: %s", err)
+	}
+
+	payload, kid, err := verifyJws(p, jws, keys, nil)
+	if err != nil {
+		return out, err
+	}
+
+	var claims jwt.Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return out, fmt.Errorf("can't unmarshal claims: %s", err)
+	}
+
+	leeway := jwt.DefaultLeeway
+	if m.Leeway.ValueString() != "" {
+		leeway, err = time.ParseDuration(m.Leeway.ValueString())
+		if err != nil {
+			return out, fmt.Errorf("invalid leeway: %s", err)
+		}
+	}
+	expected := jwt.Expected{Issuer: m.ExpectedIssuer.ValueString()}
+	if audience := m.ExpectedAudience.ValueString(); audience != "" {
+		expected.AnyAudience = jwt.Audience{audience}
+	}
+	if err := claims.ValidateWithLeeway(expected, leeway); err != nil {
+		return out, err
+	}
+
+	out.Claims = types.StringValue(string(payload))
+	out.ExpiresAt = types.StringNull()
+	if claims.Expiry != nil {
+		out.ExpiresAt = types.StringValue(claims.Expiry.Time().UTC().Format(time.RFC3339))
+	}
+	out.Issuer = types.StringValue(claims.Issuer)
+	out.KeyId = types.StringValue(kid)
+	out.Subject = types.StringValue(claims.Subject)
+	return out, nil
+}
+
+// verifyJws verifies the signatures of jws, in order, with the keys matching
+// their kid, or with every key when they have none, and returns the payload
+// and the kid of the key that verified the first valid one. The signatures
+// are verified over detachedPayload when it is not nil. The algorithms are
+// expected to be checked when parsing jws, the keys are checked against the
+// policy of p.
+func verifyJws(p *JwkProviderData, jws *jose.JSONWebSignature, keys []json.RawMessage, detachedPayload []byte) ([]byte, string, error) {
+	if len(jws.Signatures) == 0 {
+		return nil, "", fmt.Errorf("no signature found")
+	}
+
+	var lastErr error
+	for _, signature := range jws.Signatures {
+		// go-jose only verifies JWSs holding a single signature.
+		single := *jws
+		single.Signatures = []jose.Signature{signature}
+
+		var payload []byte
+		var kid string
+		payload, kid, lastErr = verifySignature(p, &single, keys, detachedPayload)
+		if lastErr == nil {
+			return payload, kid, nil
+		}
+	}
+	return nil, "", lastErr
+}
+
+// verifySignature verifies the single signature of jws, see verifyJws.
+func verifySignature(p *JwkProviderData, jws *jose.JSONWebSignature, keys []json.RawMessage, detachedPayload []byte) ([]byte, string, error) {
+	header := jws.Signatures[0].Header
+
+	var lastErr error = fmt.Errorf("no key matching kid %q", header.KeyID)
+	for _, key := range keys {
+		jwk, err := jwkutil.ParseJwk(string(key))
+		if err != nil {
+			return nil, "", fmt.Errorf("can't unmarshal JWK: %s", err)
+		}
+		if header.KeyID != "" && jwk.KeyID != header.KeyID {
+			continue
+		}
+		if err := p.checkVerificationKey(jwk.Key); err != nil {
+			lastErr = fmt.Errorf("key rejected by the provider policy: %s", err)
+			continue
+		}
+
+		if detachedPayload != nil {
+			err = jws.DetachedVerify(detachedPayload, jwkutil.PublicKey(jwk))
+			if err == nil {
+				return detachedPayload, jwk.KeyID, nil
+			}
+		} else {
+			var payload []byte
+			payload, err = jws.Verify(jwkutil.PublicKey(jwk))
+			if err == nil {
+				return payload, jwk.KeyID, nil
+			}
+		}
+		lastErr = err
+	}
+	return nil, "", lastErr
+}
+
+// signingKey returns the key signing with the private JWK jwkData and alg,
+// see jwkutil.SignatureAlgorithm.
+func signingKey(jwkData, alg string) (jose.SigningKey, error) {
+	jwk, err := jwkutil.ParseJwk(jwkData)
+	if err != nil {
+		return jose.SigningKey{}, fmt.Errorf("can't unmarshal JWK: %s", err)
+	}
+	if jwk.IsPublic() {
+		return jose.SigningKey{}, fmt.Errorf("a private JWK is required to sign")
+	}
+
+	algorithm, err := jwkutil.SignatureAlgorithm(jwk, alg)
+	if err != nil {
+		return jose.SigningKey{}, err
+	}
+	return jose.SigningKey{Algorithm: algorithm, Key: jwk}, nil
+}
+
+// newJwtSigner returns a signer producing JWTs with the extra headers.
+func newJwtSigner(key jose.SigningKey, headers map[string]string) (jose.Signer, error) {
+	return newJwsSigner(key, (&jose.SignerOptions{}).WithType("JWT"), headers)
+}
+
+// newJwsSigner returns a signer using opts with the extra headers.
+func newJwsSigner(key jose.SigningKey, opts *jose.SignerOptions, headers map[string]string) (jose.Signer, error) {
+	for name, value := range headers {
+		opts = opts.WithHeader(jose.HeaderKey(name), value)
+	}
+	return jose.NewSigner(key, opts)
+}
+
+// signJwt signs the claims with signer and returns the compact serialized
+// token. The claims are marshaled with encoding/json to keep large numbers
+// intact.
+func signJwt(signer jose.Signer, claims map[string]any) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("can't marshal claims: %s", err)
+	}
+
+	jws, err := signer.Sign(payload)
+	if err != nil {
+		return "", err
+	}
+	return jws.CompactSerialize()
+}
@@ -0,0 +1,324 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+// K8sAuthModel holds the attributes used to connect to a K8S API server. It
+// is meant to be embedded in the data source model.
+type K8sAuthModel struct {
+	ClientCertificate    types.String `tfsdk:"client_certificate"`
+	ClientKey            types.String `tfsdk:"client_key"`
+	ClusterCACertificate types.String `tfsdk:"cluster_ca_certificate"`
+	ConfigContext        types.String `tfsdk:"config_context"`
+	ConfigPath           types.String `tfsdk:"config_path"`
+	Host                 types.String `tfsdk:"host"`
+	Token                types.String `tfsdk:"token"`
+}
+
+// K8sConfig is the subset of a kubeconfig file used to connect to an API
+// server.
+type K8sConfig struct {
+	Clusters       []K8sConfigCluster `yaml:"clusters"`
+	Contexts       []K8sConfigContext `yaml:"contexts"`
+	CurrentContext string             `yaml:"current-context"`
+	Users          []K8sConfigUser    `yaml:"users"`
+}
+
+type K8sConfigCluster struct {
+	Name    string `yaml:"name"`
+	Cluster struct {
+		CertificateAuthority     string `yaml:"certificate-authority"`
+		CertificateAuthorityData string `yaml:"certificate-authority-data"`
+		Server                   string `yaml:"server"`
+	} `yaml:"cluster"`
+}
+
+type K8sConfigContext struct {
+	Name    string `yaml:"name"`
+	Context struct {
+		Cluster string `yaml:"cluster"`
+		User    string `yaml:"user"`
+	} `yaml:"context"`
+}
+
+type K8sConfigUser struct {
+	Name string `yaml:"name"`
+	User struct {
+		ClientCertificate     string `yaml:"client-certificate"`
+		ClientCertificateData string `yaml:"client-certificate-data"`
+		ClientKey             string `yaml:"client-key"`
+		ClientKeyData         string `yaml:"client-key-data"`
+		Token                 string `yaml:"token"`
+		TokenFile             string `yaml:"tokenFile"`
+	} `yaml:"user"`
+}
+
+// k8sConnection holds the resolved settings used to connect to an API server.
+type k8sConnection struct {
+	caCertificate     string
+	clientCertificate string
+	clientKey         string
+	host              string
+	token             string
+}
+
+// withK8sAuthAttributes adds the K8sAuthModel attributes to attrs.
+func withK8sAuthAttributes(attrs map[string]schema.Attribute) map[string]schema.Attribute {
+	attrs["client_certificate"] = schema.StringAttribute{
+		MarkdownDescription: "K8S Client Certificate, defaults to `KUBE_CLIENT_CERT_DATA` or the kubeconfig user",
+		Optional:            true,
+		Validators:          []validator.String{pemValidator{}},
+	}
+	attrs["client_key"] = schema.StringAttribute{
+		MarkdownDescription: "K8S Client Key, defaults to `KUBE_CLIENT_KEY_DATA` or the kubeconfig user",
+		Optional:            true,
+		Sensitive:           true,
+		Validators:          []validator.String{pemValidator{privateKey: true}},
+	}
+	attrs["cluster_ca_certificate"] = schema.StringAttribute{
+		MarkdownDescription: "K8S Cluster Certificate, defaults to `KUBE_CLUSTER_CA_CERT_DATA` or the kubeconfig cluster. The provider `ca_bundle` and the system roots are trusted when none is set",
+		Optional:            true,
+		Validators:          []validator.String{pemValidator{}},
+	}
+	attrs["host"] = schema.StringAttribute{
+		MarkdownDescription: "K8S Host, defaults to `KUBE_HOST` or the kubeconfig cluster",
+		Optional:            true,
+	}
+	attrs["token"] = schema.StringAttribute{
+		MarkdownDescription: "K8S bearer token, defaults to `KUBE_TOKEN` or the kubeconfig user",
+		Optional:            true,
+		Sensitive:           true,
+	}
+	attrs["config_path"] = schema.StringAttribute{
+		MarkdownDescription: "Path of the kubeconfig file providing the settings not set otherwise, defaults to `KUBE_CONFIG_PATH` or `KUBECONFIG`, which can list several files",
+		Optional:            true,
+	}
+	attrs["config_context"] = schema.StringAttribute{
+		MarkdownDescription: "Context of the kubeconfig file, defaults to `KUBE_CTX` or its current context",
+		Optional:            true,
+	}
+	return attrs
+}
+
+// connection resolves the connection settings from the attributes, the
+// environment and the kubeconfig file, in that order.
+func (m K8sAuthModel) connection() (k8sConnection, error) {
+	conn := k8sConnection{
+		caCertificate:     firstNonEmpty(m.ClusterCACertificate.ValueString(), os.Getenv("KUBE_CLUSTER_CA_CERT_DATA")),
+		clientCertificate: firstNonEmpty(m.ClientCertificate.ValueString(), os.Getenv("KUBE_CLIENT_CERT_DATA")),
+		clientKey:         firstNonEmpty(m.ClientKey.ValueString(), os.Getenv("KUBE_CLIENT_KEY_DATA")),
+		host:              firstNonEmpty(m.Host.ValueString(), os.Getenv("KUBE_HOST")),
+		token:             firstNonEmpty(m.Token.ValueString(), os.Getenv("KUBE_TOKEN")),
+	}
+
+	configPath := firstNonEmpty(m.ConfigPath.ValueString(), os.Getenv("KUBE_CONFIG_PATH"), os.Getenv("KUBECONFIG"))
+	if configPath != "" {
+		configConn, err := k8sConfigConnection(configPath, firstNonEmpty(m.ConfigContext.ValueString(), os.Getenv("KUBE_CTX")))
+		if err != nil {
+			return conn, err
+		}
+		conn.caCertificate = firstNonEmpty(conn.caCertificate, configConn.caCertificate)
+		conn.host = firstNonEmpty(conn.host, configConn.host)
+		// The kubeconfig credentials are only used when none is set otherwise.
+		if conn.clientCertificate == "" && conn.clientKey == "" && conn.token == "" {
+			conn.clientCertificate = configConn.clientCertificate
+			conn.clientKey = configConn.clientKey
+			conn.token = configConn.token
+		}
+	}
+
+	if conn.host == "" {
+		return conn, fmt.Errorf("no host configured, set host, KUBE_HOST or a kubeconfig file")
+	}
+	conn.host = strings.TrimRight(conn.host, "/")
+	return conn, nil
+}
+
+// httpClient returns an HTTP client of p authenticating to the API server,
+// its token only sent to the API server host.
+func (c k8sConnection) httpClient(p *JwkProviderData) (*http.Client, error) {
+	tlsConfig, err := c.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	client := p.newHTTPClient(tlsConfig)
+	if c.token != "" {
+		client = withHeaders(client, c.host, map[string]string{"Authorization": "Bearer " + c.token})
+	}
+	return client, nil
+}
+
+// tlsConfig returns the TLS configuration authenticating to the API server.
+func (c k8sConnection) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+
+	if c.clientCertificate != "" || c.clientKey != "" {
+		cert, err := tls.X509KeyPair([]byte(c.clientCertificate), []byte(c.clientKey))
+		if err != nil {
+			return nil, fmt.Errorf("can't create X509: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if c.caCertificate != "" {
+		caCertPool := x509.NewCertPool()
+		if ok := caCertPool.AppendCertsFromPEM([]byte(c.caCertificate)); !ok {
+			return nil, fmt.Errorf("can't load cluster CA")
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	return tlsConfig, nil
+}
+
+// k8sConfigConnection reads the settings of context, the current context when
+// empty, from the kubeconfig files listed in paths. Like kubectl, missing
+// files are ignored and the first file defining a cluster, a context or a
+// user wins.
+func k8sConfigConnection(paths, context string) (k8sConnection, error) {
+	var conn k8sConnection
+
+	var config K8sConfig
+	read := false
+	for _, path := range filepath.SplitList(paths) {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			continue
+		}
+		fileConfig, err := readK8sConfig(path)
+		if err != nil {
+			return conn, err
+		}
+		read = true
+		if config.CurrentContext == "" {
+			config.CurrentContext = fileConfig.CurrentContext
+		}
+		config.Clusters = append(config.Clusters, fileConfig.Clusters...)
+		config.Contexts = append(config.Contexts, fileConfig.Contexts...)
+		config.Users = append(config.Users, fileConfig.Users...)
+	}
+
+	if !read {
+		return conn, fmt.Errorf("no kubeconfig file found in %s", paths)
+	}
+
+	if context == "" {
+		context = config.CurrentContext
+	}
+	contextIdx := slices.IndexFunc(config.Contexts, func(c K8sConfigContext) bool { return c.Name == context })
+	if contextIdx < 0 {
+		return conn, fmt.Errorf("context %q not found in kubeconfig", context)
+	}
+	clusterName, userName := config.Contexts[contextIdx].Context.Cluster, config.Contexts[contextIdx].Context.User
+
+	clusterIdx := slices.IndexFunc(config.Clusters, func(c K8sConfigCluster) bool { return c.Name == clusterName })
+	if clusterIdx < 0 {
+		return conn, fmt.Errorf("cluster %q not found in kubeconfig", clusterName)
+	}
+	cluster := config.Clusters[clusterIdx].Cluster
+
+	var err error
+	conn.host = cluster.Server
+	if conn.caCertificate, err = k8sConfigData(cluster.CertificateAuthorityData, cluster.CertificateAuthority); err != nil {
+		return conn, fmt.Errorf("can't read certificate authority of cluster %q: %s", clusterName, err)
+	}
+
+	userIdx := slices.IndexFunc(config.Users, func(u K8sConfigUser) bool { return u.Name == userName })
+	if userIdx < 0 {
+		return conn, nil
+	}
+	user := config.Users[userIdx].User
+
+	if conn.clientCertificate, err = k8sConfigData(user.ClientCertificateData, user.ClientCertificate); err != nil {
+		return conn, fmt.Errorf("can't read client certificate of user %q: %s", userName, err)
+	}
+	if conn.clientKey, err = k8sConfigData(user.ClientKeyData, user.ClientKey); err != nil {
+		return conn, fmt.Errorf("can't read client key of user %q: %s", userName, err)
+	}
+	conn.token = user.Token
+	if conn.token == "" {
+		token, err := k8sConfigData("", user.TokenFile)
+		if err != nil {
+			return conn, fmt.Errorf("can't read token of user %q: %s", userName, err)
+		}
+		conn.token = strings.TrimSpace(token)
+	}
+	return conn, nil
+}
+
+// readK8sConfig reads the kubeconfig file at path, making the paths it holds
+// relative to its directory.
+func readK8sConfig(path string) (K8sConfig, error) {
+	var config K8sConfig
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, fmt.Errorf("can't read kubeconfig: %s", err)
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("can't unmarshal kubeconfig %s: %s", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	resolve := func(file *string) {
+		if *file != "" && !filepath.IsAbs(*file) {
+			*file = filepath.Join(dir, *file)
+		}
+	}
+	for i := range config.Clusters {
+		resolve(&config.Clusters[i].Cluster.CertificateAuthority)
+	}
+	for i := range config.Users {
+		resolve(&config.Users[i].User.ClientCertificate)
+		resolve(&config.Users[i].User.ClientKey)
+		resolve(&config.Users[i].User.TokenFile)
+	}
+	return config, nil
+}
+
+// k8sConfigData returns the base64 encoded data of a kubeconfig entry, or the
+// content of its file.
+func k8sConfigData(data, path string) (string, error) {
+	if data != "" {
+		decoded, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return "", err
+		}
+		return string(decoded), nil
+	}
+	if path == "" {
+		return "", nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// firstNonEmpty returns the first value that is not empty.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
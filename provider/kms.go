@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+// remoteSigner is a jose.OpaqueSigner delegating the signature of the payload
+// digest to a key management service, so the private key never leaves it.
+type remoteSigner struct {
+	public jose.JSONWebKey
+	algs   []jose.SignatureAlgorithm
+	// derSignatures is set when the service returns ECDSA signatures ASN.1
+	// DER encoded instead of the raw r || s expected by JWS.
+	derSignatures bool
+	sign          func(alg jose.SignatureAlgorithm, digest []byte) ([]byte, error)
+}
+
+var _ jose.OpaqueSigner = &remoteSigner{}
+
+// newRemoteSigner returns a signer for publicKey, whose kid is the RFC 7638
+// thumbprint of the key.
+func newRemoteSigner(publicKey crypto.PublicKey, algs []jose.SignatureAlgorithm, derSignatures bool, sign func(alg jose.SignatureAlgorithm, digest []byte) ([]byte, error)) (*remoteSigner, error) {
+	public := jose.JSONWebKey{Key: publicKey, Use: "sig"}
+	thumbprint, err := public.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("can't compute thumbprint: %s", err)
+	}
+	public.KeyID = base64.RawURLEncoding.EncodeToString(thumbprint)
+
+	return &remoteSigner{
+		public:        public,
+		algs:          algs,
+		derSignatures: derSignatures,
+		sign:          sign,
+	}, nil
+}
+
+// signingKey returns the signing key for alg, falling back to the usual
+// algorithm for the key type, which must be supported by the service.
+func (s *remoteSigner) signingKey(alg string) (jose.SigningKey, error) {
+	algorithm, err := jwkutil.SignatureAlgorithm(s.public, alg)
+	if err != nil {
+		return jose.SigningKey{}, err
+	}
+	if !slices.Contains(s.algs, algorithm) {
+		return jose.SigningKey{}, fmt.Errorf("algorithm %s is not supported by the key", algorithm)
+	}
+	return jose.SigningKey{Algorithm: algorithm, Key: s}, nil
+}
+
+// signJwt signs a JWT with the claims and extra headers, encrypting it when
+// configured, and returns it along with the public JWK of the key. The key
+// must comply with the policy of p.
+func (s *remoteSigner) signJwt(ctx context.Context, p *JwkProviderData, alg types.String, headersAttr types.Map, claimsModel JwtClaimsModel, encryption JweEncryptionModel) (string, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	key, err := s.signingKey(alg.ValueString())
+	if err != nil {
+		diags.AddError("signingKey", fmt.Sprintf("Can't load signing key : %s", err))
+		return "", "", diags
+	}
+	if err := p.checkSigningKey(key); err != nil {
+		diags.AddError("checkSigningKey", fmt.Sprintf("Signing key rejected by the provider policy : %s", err))
+		return "", "", diags
+	}
+
+	headers := map[string]string{}
+	if !headersAttr.IsNull() {
+		diags.Append(headersAttr.ElementsAs(ctx, &headers, false)...)
+		if diags.HasError() {
+			return "", "", diags
+		}
+	}
+
+	claims, err := claimsModel.claims(time.Now())
+	if err != nil {
+		diags.AddError("claims", fmt.Sprintf("Can't build claims : %s", err))
+		return "", "", diags
+	}
+
+	signer, err := newJwtSigner(key, headers)
+	if err != nil {
+		diags.AddError("NewSigner", fmt.Sprintf("Can't create signer : %s", err))
+		return "", "", diags
+	}
+
+	token, err := signJwt(signer, claims)
+	if err != nil {
+		diags.AddError("signJwt", fmt.Sprintf("Can't sign token : %s", err))
+		return "", "", diags
+	}
+
+	token, err = encryption.encrypt(p, token)
+	if err != nil {
+		diags.AddError("encrypt", fmt.Sprintf("Can't encrypt token : %s", err))
+		return "", "", diags
+	}
+
+	jwk, err := s.public.MarshalJSON()
+	if err != nil {
+		diags.AddError("MarshalJSON", fmt.Sprintf("Can't marshal public JWK : %s", err))
+		return "", "", diags
+	}
+
+	return token, string(jwk), diags
+}
+
+func (s *remoteSigner) Public() *jose.JSONWebKey {
+	return &s.public
+}
+
+func (s *remoteSigner) Algs() []jose.SignatureAlgorithm {
+	return s.algs
+}
+
+func (s *remoteSigner) SignPayload(payload []byte, alg jose.SignatureAlgorithm) ([]byte, error) {
+	hash, err := signatureHash(alg)
+	if err != nil {
+		return nil, err
+	}
+	hasher := hash.New()
+	hasher.Write(payload)
+
+	signature, err := s.sign(alg, hasher.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	if key, ok := s.public.Key.(*ecdsa.PublicKey); ok && s.derSignatures {
+		return ecdsaRawSignature(signature, (key.Curve.Params().BitSize+7)/8)
+	}
+	return signature, nil
+}
+
+// signatureHash returns the hash function of the RSA and ECDSA alg.
+func signatureHash(alg jose.SignatureAlgorithm) (crypto.Hash, error) {
+	switch alg {
+	case jose.RS256, jose.PS256, jose.ES256:
+		return crypto.SHA256, nil
+	case jose.RS384, jose.PS384, jose.ES384:
+		return crypto.SHA384, nil
+	case jose.RS512, jose.PS512, jose.ES512:
+		return crypto.SHA512, nil
+	default:
+		return 0, fmt.Errorf("unsupported algorithm %s", alg)
+	}
+}
+
+// ecdsaRawSignature converts an ASN.1 DER ECDSA signature to r || s, each
+// padded to size bytes.
+func ecdsaRawSignature(der []byte, size int) ([]byte, error) {
+	var signature struct {
+		R, S *big.Int
+	}
+	rest, err := asn1.Unmarshal(der, &signature)
+	if err != nil {
+		return nil, fmt.Errorf("can't unmarshal ECDSA signature: %s", err)
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("trailing data after ECDSA signature")
+	}
+	for _, n := range []*big.Int{signature.R, signature.S} {
+		if n.Sign() <= 0 || n.BitLen() > 8*size {
+			return nil, fmt.Errorf("ECDSA signature integers must be positive and fit in %d bytes", size)
+		}
+	}
+
+	raw := make([]byte, 2*size)
+	signature.R.FillBytes(raw[:size])
+	signature.S.FillBytes(raw[size:])
+	return raw, nil
+}
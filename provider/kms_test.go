@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+)
+
+func TestEcdsaRawSignature(t *testing.T) {
+	marshal := func(r, s *big.Int) []byte {
+		der, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return der
+	}
+	oversized := new(big.Int).Lsh(big.NewInt(1), 256)
+
+	tests := []struct {
+		name    string
+		der     []byte
+		want    []byte
+		wantErr bool
+	}{
+		{name: "padded", der: marshal(big.NewInt(1), big.NewInt(258)), want: append(append(make([]byte, 31), 1), append(make([]byte, 30), 1, 2)...)},
+		{name: "full size", der: marshal(new(big.Int).Sub(oversized, big.NewInt(1)), big.NewInt(1)), want: append(bytes.Repeat([]byte{0xff}, 32), append(make([]byte, 31), 1)...)},
+		{name: "oversized r", der: marshal(oversized, big.NewInt(1)), wantErr: true},
+		{name: "oversized s", der: marshal(big.NewInt(1), oversized), wantErr: true},
+		{name: "negative r", der: marshal(big.NewInt(-1), big.NewInt(1)), wantErr: true},
+		{name: "zero s", der: marshal(big.NewInt(1), big.NewInt(0)), wantErr: true},
+		{name: "trailing data", der: append(marshal(big.NewInt(1), big.NewInt(1)), 0), wantErr: true},
+		{name: "not der", der: []byte{1, 2, 3}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ecdsaRawSignature(tt.der, 32)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ecdsaRawSignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("ecdsaRawSignature() = %x, want %x", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// defaultMaxConcurrentRequests bounds the requests sent at once to remote
+// endpoints when max_concurrent_requests is not set.
+const defaultMaxConcurrentRequests = 16
+
+// limitTransport bounds the requests sent at once through base to the
+// capacity of slots, shared by every data source. A slot is held until the
+// response body is closed.
+type limitTransport struct {
+	base  http.RoundTripper
+	slots chan struct{}
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	release := sync.OnceFunc(func() { <-t.slots })
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &releaseBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releaseBody calls release once the body is closed.
+type releaseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
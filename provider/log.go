@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redactedFieldKeys are the log fields whose values are always masked.
+var redactedFieldKeys = []string{"authorization", "d", "dp", "dq", "k", "p", "q", "qi", "token"}
+
+// redactedRegexps match the key material and tokens masked in every log
+// message and field value.
+var redactedRegexps = []*regexp.Regexp{
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`),
+	regexp.MustCompile(`"(d|dp|dq|k|p|q|qi)"\s*:\s*"[^"]*"`),
+	regexp.MustCompile(`eyJ[\w-]*\.[\w-]*\.[\w-]*`),
+}
+
+// withRedaction returns ctx masking key material in the logs it emits, meant
+// to be used by every tflog call of the provider.
+func withRedaction(ctx context.Context) context.Context {
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, redactedFieldKeys...)
+	ctx = tflog.MaskAllFieldValuesRegexes(ctx, redactedRegexps...)
+	return tflog.MaskMessageRegexes(ctx, redactedRegexps...)
+}
+
+// logUrl returns u without its user info and query values, which may
+// carry credentials.
+func logUrl(u *url.URL) string {
+	redacted := *u
+	redacted.User = nil
+	query := redacted.Query()
+	for name := range query {
+		query.Set(name, "REDACTED")
+	}
+	redacted.RawQuery = query.Encode()
+	return redacted.String()
+}
+
+// logTransport logs every request sent through base along with its outcome,
+// and their connection events when trace is set.
+type logTransport struct {
+	base  http.RoundTripper
+	trace bool
+}
+
+func (t *logTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fields := map[string]any{
+		"method": req.Method,
+		"url":    logUrl(req.URL),
+	}
+	if t.trace {
+		req = withHTTPTrace(req)
+		headerNames := make([]string, 0, len(req.Header))
+		for name := range req.Header {
+			headerNames = append(headerNames, name)
+		}
+		slices.Sort(headerNames)
+		fields["request_headers"] = headerNames
+		if transport, ok := t.base.(*http.Transport); ok && transport.Proxy != nil {
+			if proxyUrl, err := transport.Proxy(req); err == nil && proxyUrl != nil {
+				fields["proxy"] = logUrl(proxyUrl)
+			}
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+
+	fields["duration"] = time.Since(start).String()
+	if err != nil {
+		fields["error"] = err.Error()
+	} else {
+		fields["status"] = resp.StatusCode
+		if t.trace {
+			fields["proto"] = resp.Proto
+			fields["content_type"] = resp.Header.Get("Content-Type")
+			fields["content_length"] = resp.ContentLength
+		}
+	}
+	tflog.Debug(withRedaction(req.Context()), "HTTP request", fields)
+
+	return resp, err
+}
+
+// withHTTPTrace returns req logging its DNS, connection, TLS and transfer
+// events, never the bodies.
+func withHTTPTrace(req *http.Request) *http.Request {
+	ctx := withRedaction(req.Context())
+	reqUrl := logUrl(req.URL)
+	event := func(name string, err error, fields map[string]any) {
+		fields["event"] = name
+		fields["url"] = reqUrl
+		if err != nil {
+			fields["error"] = err.Error()
+		}
+		tflog.Debug(ctx, "HTTP trace", fields)
+	}
+
+	trace := &httptrace.ClientTrace{
+		GetConn: func(hostPort string) {
+			event("get_conn", nil, map[string]any{"host_port": hostPort})
+		},
+		DNSStart: func(info httptrace.DNSStartInfo) {
+			event("dns_start", nil, map[string]any{"host": info.Host})
+		},
+		DNSDone: func(info httptrace.DNSDoneInfo) {
+			addrs := make([]string, 0, len(info.Addrs))
+			for _, addr := range info.Addrs {
+				addrs = append(addrs, addr.String())
+			}
+			event("dns_done", info.Err, map[string]any{"addrs": addrs})
+		},
+		ConnectStart: func(network, addr string) {
+			event("connect_start", nil, map[string]any{"network": network, "addr": addr})
+		},
+		ConnectDone: func(network, addr string, err error) {
+			event("connect_done", err, map[string]any{"network": network, "addr": addr})
+		},
+		TLSHandshakeStart: func() {
+			event("tls_handshake_start", nil, map[string]any{})
+		},
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			var certificates []string
+			for _, cert := range state.PeerCertificates {
+				certificates = append(certificates, fmt.Sprintf("%s, issued by %s, expires %s", cert.Subject, cert.Issuer, cert.NotAfter.UTC().Format(time.RFC3339)))
+			}
+			event("tls_handshake_done", err, map[string]any{
+				"version":             tls.VersionName(state.Version),
+				"cipher_suite":        tls.CipherSuiteName(state.CipherSuite),
+				"server_name":         state.ServerName,
+				"negotiated_protocol": state.NegotiatedProtocol,
+				"peer_certificates":   certificates,
+			})
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			event("got_conn", nil, map[string]any{
+				"local_addr":  info.Conn.LocalAddr().String(),
+				"remote_addr": info.Conn.RemoteAddr().String(),
+				"reused":      info.Reused,
+				"was_idle":    info.WasIdle,
+			})
+		},
+		WroteRequest: func(info httptrace.WroteRequestInfo) {
+			event("wrote_request", info.Err, map[string]any{})
+		},
+		GotFirstResponseByte: func() {
+			event("got_first_response_byte", nil, map[string]any{})
+		},
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// mockFixturesEnv names the environment variable holding the path of the
+// fixtures served, instead of the remote endpoints, by an in-process mock
+// server. It is meant for the acceptance tests of the configurations using
+// the provider.
+const mockFixturesEnv = "TF_JWK_MOCK_FIXTURES"
+
+// mockUrlHeader carries the URL requested by a data source to the mock
+// server.
+const mockUrlHeader = "X-Jwk-Mock-Url"
+
+// MockFixture is the response served by the mock server for a URL. A JSON
+// string body is served decoded, any other JSON value as is.
+type MockFixture struct {
+	Body    json.RawMessage   `json:"body"`
+	Headers map[string]string `json:"headers"`
+	Status  int               `json:"status"`
+}
+
+var mockServer struct {
+	once   sync.Once
+	server *httptest.Server
+	err    error
+}
+
+// mockTransport sends every request to the mock server, failing with err
+// when the server couldn't be started.
+type mockTransport struct {
+	server *httptest.Server
+	err    error
+}
+
+func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+
+	mockReq := req.Clone(req.Context())
+	mockReq.Header.Set(mockUrlHeader, req.URL.String())
+	mockReq.URL.Scheme = "http"
+	mockReq.URL.Host = t.server.Listener.Addr().String()
+	mockReq.Host = ""
+	return t.server.Client().Transport.RoundTrip(mockReq)
+}
+
+// newMockTransport returns the transport of the mock server, started on the
+// first call, nil when mockFixturesEnv is not set.
+func newMockTransport() *mockTransport {
+	path := os.Getenv(mockFixturesEnv)
+	if path == "" {
+		return nil
+	}
+
+	mockServer.once.Do(func() {
+		mockServer.server, mockServer.err = startMockServer(path)
+	})
+	return &mockTransport{server: mockServer.server, err: mockServer.err}
+}
+
+// startMockServer starts a server answering with the fixtures of the JSON
+// file at path, a map of URLs to MockFixture. A request matches the fixture
+// of its URL, without query when there is none for it. The others get a 404.
+func startMockServer(path string) (*httptest.Server, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't read %s fixtures: %s", mockFixturesEnv, err)
+	}
+	var fixtures map[string]MockFixture
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, fmt.Errorf("can't unmarshal %s fixtures: %s", mockFixturesEnv, err)
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestUrl := r.Header.Get(mockUrlHeader)
+		fixture, ok := fixtures[requestUrl]
+		if !ok {
+			if mockUrl, err := url.Parse(requestUrl); err == nil {
+				mockUrl.RawQuery = ""
+				fixture, ok = fixtures[mockUrl.String()]
+			}
+		}
+		if !ok {
+			http.Error(w, fmt.Sprintf("no fixture for %s", requestUrl), http.StatusNotFound)
+			return
+		}
+
+		body := []byte(fixture.Body)
+		var text string
+		if json.Unmarshal(fixture.Body, &text) == nil {
+			body = []byte(text)
+		} else if _, ok := fixture.Headers["Content-Type"]; !ok {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		for name, value := range fixture.Headers {
+			w.Header().Set(name, value)
+		}
+		status := fixture.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		w.WriteHeader(status)
+		w.Write(body)
+
+		tflog.Debug(r.Context(), "Served mock fixture", map[string]any{"url": requestUrl, "status": status})
+	})), nil
+}
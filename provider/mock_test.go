@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testFixtures are the mock fixtures of a Dex instance rotating its keys.
+var testFixtures = map[string]MockFixture{
+	"https://dex.example.com/.well-known/openid-configuration": {
+		Body: json.RawMessage(`{"issuer":"https://dex.example.com","jwks_uri":"https://dex.example.com/keys"}`),
+	},
+	"https://dex.example.com/keys": {
+		Body:    json.RawMessage(`{"keys":[{"kid":"signing","kty":"oct","k":"AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8"},{"kid":"rotated","kty":"oct","k":"AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8"}]}`),
+		Headers: map[string]string{"Cache-Control": "max-age=60"},
+	},
+	"https://broken.example.com/.well-known/openid-configuration": {
+		Body:   json.RawMessage(`"unavailable"`),
+		Status: 503,
+	},
+}
+
+// testProviderServer returns a configured provider server, answered by the
+// mock server serving fixtures, along with its schemas. The mock server is
+// only started once per process, so every test must use the same fixtures.
+func testProviderServer(t *testing.T, fixtures map[string]MockFixture) (tfprotov6.ProviderServer, *tfprotov6.GetProviderSchemaResponse) {
+	t.Helper()
+
+	fixturesData, err := json.Marshal(fixtures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixturesPath := filepath.Join(t.TempDir(), "fixtures.json")
+	if err := os.WriteFile(fixturesPath, fixturesData, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(mockFixturesEnv, fixturesPath)
+
+	return newTestProviderServer(t)
+}
+
+func TestJwkFromDexDataSourceMock(t *testing.T) {
+	server, schemas := testProviderServer(t, testFixtures)
+
+	t.Run("rotation", func(t *testing.T) {
+		state, diags := readDataSource(t, server, schemas, "jwk_from_dex", map[string]tftypes.Value{
+			"issuer": tftypes.NewValue(tftypes.String, "https://dex.example.com"),
+		})
+		checkDiagnostics(t, diags)
+
+		if got := stringValue(t, state["jwks_uri"]); got != "https://dex.example.com/keys" {
+			t.Errorf("jwks_uri = %q, want %q", got, "https://dex.example.com/keys")
+		}
+		if got := stringValue(t, state["signing_kid"]); got != "signing" {
+			t.Errorf("signing_kid = %q, want %q", got, "signing")
+		}
+		if got := stringListValue(t, state["verification_kids"]); !slices.Equal(got, []string{"rotated"}) {
+			t.Errorf("verification_kids = %v, want [rotated]", got)
+		}
+		if got := stringListValue(t, state["jwks"]); len(got) != 2 {
+			t.Errorf("jwks has %d keys, want 2", len(got))
+		}
+		if stringValue(t, state["next_rotation"]) == "" {
+			t.Errorf("next_rotation is empty, want the Cache-Control max-age")
+		}
+	})
+
+	t.Run("unavailable issuer", func(t *testing.T) {
+		_, diags := readDataSource(t, server, schemas, "jwk_from_dex", map[string]tftypes.Value{
+			"issuer": tftypes.NewValue(tftypes.String, "https://broken.example.com"),
+		})
+		if !slices.ContainsFunc(diags, func(diag *tfprotov6.Diagnostic) bool {
+			return diag.Severity == tfprotov6.DiagnosticSeverityError && diag.Summary == "fetchOidcDiscovery"
+		}) {
+			t.Errorf("diagnostics = %v, want a fetchOidcDiscovery error", diags)
+		}
+	})
+
+	t.Run("no fixture", func(t *testing.T) {
+		_, diags := readDataSource(t, server, schemas, "jwk_from_dex", map[string]tftypes.Value{
+			"issuer": tftypes.NewValue(tftypes.String, "https://unknown.example.com"),
+		})
+		if !slices.ContainsFunc(diags, func(diag *tfprotov6.Diagnostic) bool {
+			return diag.Severity == tfprotov6.DiagnosticSeverityError
+		}) {
+			t.Errorf("diagnostics = %v, want an error for the URL without fixture", diags)
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+// fetchOidcJwks discovers the JWKS URI of issuer and fetches its keys.
+func fetchOidcJwks(ctx context.Context, client *http.Client, issuer string, opts FetchOptionsModel) (jwkutil.OidcDiscovery, []json.RawMessage, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	client, err := opts.fetchClient(client)
+	if err != nil {
+		diags.AddError("fetchClient", fmt.Sprintf("Can't configure HTTP client : %s", err))
+		return jwkutil.OidcDiscovery{}, nil, diags
+	}
+
+	discovery, err := jwkutil.FetchOidcDiscovery(ctx, client, issuer)
+	if err != nil {
+		diags.AddError("fetchOidcDiscovery", fmt.Sprintf("Fail to fetch OIDC discovery document : %s", err))
+		return discovery, nil, diags
+	}
+
+	keys, diags := fetchJwks(ctx, client, discovery.JwksUri, opts)
+	return discovery, keys, diags
+}
@@ -0,0 +1,242 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	jose "github.com/go-jose/go-jose/v4"
+)
+
+const (
+	fipsMinRsaBits       = 2048
+	fipsMinSymmetricBits = 112
+)
+
+// privateKeyMembers are the JWK members holding private or symmetric key
+// material.
+var privateKeyMembers = []string{"d", "dp", "dq", "k", "oth", "p", "q", "qi"}
+
+// fipsCurves are the elliptic curves approved by FIPS 186-5.
+var fipsCurves = []string{"P-256", "P-384", "P-521"}
+
+// fipsKeyAlgorithms are the key management algorithms approved by FIPS
+// 140-3, RSA1_5 and PBES2 being excluded.
+var fipsKeyAlgorithms = []jose.KeyAlgorithm{
+	jose.RSA_OAEP, jose.RSA_OAEP_256,
+	jose.A128KW, jose.A192KW, jose.A256KW,
+	jose.DIRECT,
+	jose.ECDH_ES, jose.ECDH_ES_A128KW, jose.ECDH_ES_A192KW, jose.ECDH_ES_A256KW,
+	jose.A128GCMKW, jose.A192GCMKW, jose.A256GCMKW,
+}
+
+// supportedCurves are the curves allowed_curves accepts.
+var supportedCurves = []string{"P-256", "P-384", "P-521", "Ed25519"}
+
+// keyPolicy is the organizational crypto policy set on the provider. The
+// zero value allows everything.
+type keyPolicy struct {
+	allowedCurves    []string
+	bannedAlgorithms []string
+	minRsaBits       int
+}
+
+// checkAlgorithm returns an error when alg is banned.
+func (k keyPolicy) checkAlgorithm(alg string) error {
+	if slices.Contains(k.bannedAlgorithms, alg) {
+		return fmt.Errorf("the %s algorithm is listed in banned_algorithms", alg)
+	}
+	return nil
+}
+
+// checkKey returns an error when key is smaller or on another curve than the
+// policy allows.
+func (k keyPolicy) checkKey(key any) error {
+	switch key := publicKey(key).(type) {
+	case *rsa.PublicKey:
+		if bits := key.N.BitLen(); bits < k.minRsaBits {
+			return fmt.Errorf("the %d bits RSA key is below min_rsa_bits, use at least %d bits", bits, k.minRsaBits)
+		}
+	case *ecdsa.PublicKey:
+		return k.checkCurve(key.Curve.Params().Name)
+	case ed25519.PublicKey:
+		return k.checkCurve("Ed25519")
+	}
+	return nil
+}
+
+func (k keyPolicy) checkCurve(name string) error {
+	if len(k.allowedCurves) > 0 && !slices.Contains(k.allowedCurves, name) {
+		return fmt.Errorf("the %s curve isn't listed in allowed_curves, use one of %v", name, k.allowedCurves)
+	}
+	return nil
+}
+
+// publicKey returns the public key of key, unwrapping JWKs and opaque
+// signers. Symmetric keys are returned as is.
+func publicKey(key any) any {
+	switch k := key.(type) {
+	case jose.JSONWebKey:
+		return publicKey(k.Key)
+	case *jose.JSONWebKey:
+		return publicKey(k.Key)
+	case jose.OpaqueSigner:
+		return publicKey(k.Public().Key)
+	case *rsa.PrivateKey:
+		return &k.PublicKey
+	case *ecdsa.PrivateKey:
+		return &k.PublicKey
+	case ed25519.PrivateKey:
+		return k.Public()
+	default:
+		return key
+	}
+}
+
+// checkSigningKey returns an error when the provider policy forbids signing
+// with key.
+func (p *JwkProviderData) checkSigningKey(key jose.SigningKey) error {
+	if p == nil {
+		return nil
+	}
+	if err := p.keyPolicy.checkAlgorithm(string(key.Algorithm)); err != nil {
+		return err
+	}
+	return p.checkKey(key.Key)
+}
+
+// checkEncryptionKey returns an error when the provider policy forbids
+// encrypting to jwk with alg and enc.
+func (p *JwkProviderData) checkEncryptionKey(jwk jose.JSONWebKey, alg jose.KeyAlgorithm, enc jose.ContentEncryption) error {
+	if p == nil {
+		return nil
+	}
+	if p.fipsMode && !slices.Contains(fipsKeyAlgorithms, alg) {
+		return fmt.Errorf("fips_mode forbids the %s key management algorithm, use RSA-OAEP-256, an AES key wrap or an ECDH-ES algorithm", alg)
+	}
+	if err := p.keyPolicy.checkAlgorithm(string(alg)); err != nil {
+		return err
+	}
+	if err := p.keyPolicy.checkAlgorithm(string(enc)); err != nil {
+		return err
+	}
+	return p.checkKey(jwk.Key)
+}
+
+// checkVerificationKey returns an error when the provider policy forbids
+// trusting key to verify signatures.
+func (p *JwkProviderData) checkVerificationKey(key any) error {
+	if p == nil {
+		return nil
+	}
+	return p.keyPolicy.checkKey(key)
+}
+
+// checkKey returns an error when the provider policy forbids converting key.
+func (p *JwkProviderData) checkKey(key any) error {
+	if p == nil {
+		return nil
+	}
+	if p.fipsMode {
+		if err := checkFipsKey(key, "fips_mode"); err != nil {
+			return err
+		}
+	}
+	return p.keyPolicy.checkKey(key)
+}
+
+// policyViolations returns every way jwk violates the provider policy, where
+// the check functions above stop at the first one.
+func (p *JwkProviderData) policyViolations(jwk jose.JSONWebKey) []string {
+	if p == nil {
+		return nil
+	}
+
+	var violations []string
+	if p.fipsMode {
+		if err := checkFipsKey(jwk.Key, "fips_mode"); err != nil {
+			violations = append(violations, err.Error())
+		}
+		if err := checkFipsAlgorithm(jwk.Algorithm, "fips_mode"); err != nil {
+			violations = append(violations, err.Error())
+		}
+	}
+	if err := p.keyPolicy.checkKey(jwk.Key); err != nil {
+		violations = append(violations, err.Error())
+	}
+	if jwk.Algorithm != "" {
+		if err := p.keyPolicy.checkAlgorithm(jwk.Algorithm); err != nil {
+			violations = append(violations, err.Error())
+		}
+	}
+	return violations
+}
+
+// checkOutputKeys returns an error when the provider disallow_private_output
+// is set and one of keys holds private or symmetric key material.
+func (p *JwkProviderData) checkOutputKeys(keys []json.RawMessage) error {
+	if p == nil || !p.disallowPrivateOutput {
+		return nil
+	}
+	for i, key := range keys {
+		if len(privateMembers(key)) > 0 {
+			return fmt.Errorf("key %d holds private key material, disallowed by disallow_private_output", i)
+		}
+	}
+	return nil
+}
+
+// privateMembers returns the members of key holding private or symmetric key
+// material, none for malformed keys.
+func privateMembers(key json.RawMessage) []string {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(key, &members); err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, name := range privateKeyMembers {
+		if _, ok := members[name]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// checkFipsKey returns an error when key isn't approved by FIPS 186-5 and
+// SP 800-131A, naming setting, the attribute enabling the check.
+func checkFipsKey(key any, setting string) error {
+	switch k := publicKey(key).(type) {
+	case *rsa.PublicKey:
+		if bits := k.N.BitLen(); bits < fipsMinRsaBits {
+			return fmt.Errorf("%s forbids %d bits RSA keys, use at least %d bits", setting, bits, fipsMinRsaBits)
+		}
+	case *ecdsa.PublicKey:
+		if name := k.Curve.Params().Name; !slices.Contains(fipsCurves, name) {
+			return fmt.Errorf("%s forbids the %s curve, use one of %v", setting, name, fipsCurves)
+		}
+	case ed25519.PublicKey:
+	case []byte:
+		if bits := len(k) * 8; bits < fipsMinSymmetricBits {
+			return fmt.Errorf("%s forbids %d bits symmetric keys, use at least %d bits", setting, bits, fipsMinSymmetricBits)
+		}
+	default:
+		return fmt.Errorf("%s forbids %T keys", setting, key)
+	}
+	return nil
+}
+
+// checkFipsAlgorithm returns an error when alg, the alg member of a key, isn't
+// approved by FIPS 140-3, naming setting like checkFipsKey.
+func checkFipsAlgorithm(alg string, setting string) error {
+	if alg == "none" {
+		return fmt.Errorf("%s forbids the none algorithm", setting)
+	}
+	if slices.Contains(supportedKeyAlgorithms, jose.KeyAlgorithm(alg)) && !slices.Contains(fipsKeyAlgorithms, jose.KeyAlgorithm(alg)) {
+		return fmt.Errorf("%s forbids the %s key management algorithm, use RSA-OAEP-256, an AES key wrap or an ECDH-ES algorithm", setting, alg)
+	}
+	return nil
+}
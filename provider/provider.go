@@ -2,29 +2,440 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
 
+	jose "github.com/go-jose/go-jose/v4"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/net/http/httpproxy"
 )
 
 var _ provider.Provider = &JwkProvider{}
+var _ provider.ProviderWithEphemeralResources = &JwkProvider{}
+var _ provider.ProviderWithFunctions = &JwkProvider{}
 
 type JwkProvider struct {
 	version string
 }
 
+type JwkProviderModel struct {
+	AllowedCurves               types.List   `tfsdk:"allowed_curves"`
+	BannedAlgorithms            types.List   `tfsdk:"banned_algorithms"`
+	Base64url                   types.String `tfsdk:"base64url"`
+	CaBundle                    types.String `tfsdk:"ca_bundle"`
+	ClientCertificate           types.String `tfsdk:"client_certificate"`
+	ClientKey                   types.String `tfsdk:"client_key"`
+	Conformance                 types.String `tfsdk:"conformance"`
+	ContentEncryptionAlgorithms types.List   `tfsdk:"content_encryption_algorithms"`
+	DebugHttp                   types.Bool   `tfsdk:"debug_http"`
+	DisallowPrivateOutput       types.Bool   `tfsdk:"disallow_private_output"`
+	ExpiryWarningDays           types.Int64  `tfsdk:"expiry_warning_days"`
+	ExtraUserAgent              types.String `tfsdk:"extra_user_agent"`
+	FipsMode                    types.Bool   `tfsdk:"fips_mode"`
+	KeyEncryptionAlgorithms     types.List   `tfsdk:"key_encryption_algorithms"`
+	MaxConcurrentRequests       types.Int64  `tfsdk:"max_concurrent_requests"`
+	MinRsaBits                  types.Int64  `tfsdk:"min_rsa_bits"`
+	NoProxy                     types.List   `tfsdk:"no_proxy"`
+	Offline                     types.Bool   `tfsdk:"offline"`
+	ProxyPassword               types.String `tfsdk:"proxy_password"`
+	ProxyUrl                    types.String `tfsdk:"proxy_url"`
+	ProxyUsername               types.String `tfsdk:"proxy_username"`
+	RetryAttempts               types.Int64  `tfsdk:"retry_attempts"`
+	RetryMaxBackoff             types.String `tfsdk:"retry_max_backoff"`
+	RetryMinBackoff             types.String `tfsdk:"retry_min_backoff"`
+	SignatureAlgorithms         types.List   `tfsdk:"signature_algorithms"`
+	Timeout                     types.String `tfsdk:"timeout"`
+	TlsMinVersion               types.String `tfsdk:"tls_min_version"`
+	WeakAlgorithms              types.String `tfsdk:"weak_algorithms"`
+}
+
 func (p *JwkProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "jwk"
 	resp.Version = p.version
 }
 
 func (p *JwkProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
-	resp.Schema = schema.Schema{}
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"ca_bundle": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificates trusted, in addition to the system roots, by the data sources reaching remote endpoints",
+				Optional:            true,
+				Validators:          []validator.String{pemValidator{}},
+			},
+			"client_certificate": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate presented to remote endpoints, along with `client_key`",
+				Optional:            true,
+				Validators:          []validator.String{pemValidator{}},
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key of `client_certificate`",
+				Optional:            true,
+				Sensitive:           true,
+				Validators:          []validator.String{pemValidator{privateKey: true}},
+			},
+			"tls_min_version": schema.StringAttribute{
+				MarkdownDescription: "Minimum TLS version accepted from remote endpoints, `1.2` or `1.3` (default: `1.2`)",
+				Optional:            true,
+			},
+			"timeout": schema.StringAttribute{
+				MarkdownDescription: "Default timeout of each request sent to remote endpoints, unlimited by default",
+				Optional:            true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of requests sent at once to remote endpoints by all the data sources, the others wait for a slot (default: `%d`)", defaultMaxConcurrentRequests),
+				Optional:            true,
+			},
+			"retry_attempts": schema.Int64Attribute{
+				MarkdownDescription: "Default number of times a failed `GET` request, or one answered with a 429, 502, 503 or 504 status, is retried (default: `0`)",
+				Optional:            true,
+			},
+			"retry_min_backoff": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Default delay before the first retry, doubled on every retry (default: `%s`)", defaultRetryMinBackoff),
+				Optional:            true,
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Default maximum delay between two retries, also bounding `Retry-After` (default: `%s`)", defaultRetryMaxBackoff),
+				Optional:            true,
+			},
+			"signature_algorithms": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Signature algorithms accepted when parsing a JWS or a JWT, the others are rejected before any verification. The data sources `algorithms` take precedence, defaults to every algorithm supported",
+				Optional:            true,
+			},
+			"key_encryption_algorithms": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Key management algorithms accepted when parsing a JWE, the others are rejected before any decryption. The data sources `key_encryption_algorithms` take precedence, defaults to every algorithm supported",
+				Optional:            true,
+			},
+			"content_encryption_algorithms": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Content encryption algorithms accepted when parsing a JWE, the others are rejected before any decryption. The data sources `content_encryption_algorithms` take precedence, defaults to every algorithm supported",
+				Optional:            true,
+			},
+			"fips_mode": schema.BoolAttribute{
+				MarkdownDescription: "Restrict signing, encryption and conversions to FIPS approved algorithms and keys: RSA keys of at least 2048 bits, the P-256, P-384 and P-521 curves, Ed25519, symmetric keys of at least 112 bits and no RSA1_5 or PBES2 key management. It also restricts the default `key_encryption_algorithms`",
+				Optional:            true,
+			},
+			"min_rsa_bits": schema.Int64Attribute{
+				MarkdownDescription: "Minimum size of the RSA keys the data sources sign, verify, encrypt or convert with, unlimited by default",
+				Optional:            true,
+			},
+			"allowed_curves": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Elliptic curves of the keys the data sources sign, verify, encrypt or convert with, among `P-256`, `P-384`, `P-521` and `Ed25519`, defaults to every curve",
+				Optional:            true,
+			},
+			"banned_algorithms": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Signature, key management and content encryption algorithms never used to sign or encrypt and rejected when parsing a JWS, a JWT or a JWE, whatever the data sources allow",
+				Optional:            true,
+			},
+			"base64url": schema.StringAttribute{
+				MarkdownDescription: "Handling of the padded, base64 alphabet or non canonical base64url members of the JWKs read by the data sources: `passthrough` keeps them as is, `normalize` rewrites them as canonical unpadded base64url and `reject` fails (default: `passthrough`)",
+				Optional:            true,
+			},
+			"expiry_warning_days": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Number of days before their expiry, from their `exp` member or their `x5c` certificate, the keys read by the data sources are reported as warnings, `0` to disable them (default: `%d`)", defaultExpiryWarningDays),
+				Optional:            true,
+			},
+			"conformance": schema.StringAttribute{
+				MarkdownDescription: "RFC conformance of the JWKs read by the data sources: `strict` rejects the keys without `kty`, listing unknown members in `crit` or declaring an `alg` their `kty`, curve or size can't be used with, `lenient` accepts them (default: `lenient`)",
+				Optional:            true,
+			},
+			"weak_algorithms": schema.StringAttribute{
+				MarkdownDescription: "How weak keys and algorithms read, converted or used by the data sources are reported: RSA keys below 2048 bits, symmetric keys shorter than their HMAC, curves other than P-256, P-384 and P-521, `none` and `RSA1_5`. `warning`, `error` or `ignore` (default: `warning`)",
+				Optional:            true,
+			},
+			"debug_http": schema.BoolAttribute{
+				MarkdownDescription: "Log the DNS, connection, TLS and proxy details of every request sent to remote endpoints, along with the metadata of their responses, at the `DEBUG` level. The bodies are never logged",
+				Optional:            true,
+			},
+			"disallow_private_output": schema.BoolAttribute{
+				MarkdownDescription: "Refuse to output private and symmetric keys, for configurations only distributing public keys. The data sources reading, decrypting or converting them fail instead",
+				Optional:            true,
+			},
+			"extra_user_agent": schema.StringAttribute{
+				MarkdownDescription: "Appended to the User-Agent sent to remote endpoints, defaults to `TF_APPEND_USER_AGENT`",
+				Optional:            true,
+			},
+			"offline": schema.BoolAttribute{
+				MarkdownDescription: "Make every data source reaching a remote endpoint fail without attempting any connection, for air-gapped environments",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the proxy used to reach remote endpoints, defaults to `HTTPS_PROXY` and `HTTP_PROXY`",
+				Optional:            true,
+			},
+			"no_proxy": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Hosts, domains, IP addresses and CIDR ranges reached without going through `proxy_url`, with the syntax of `NO_PROXY`",
+				Optional:            true,
+			},
+			"proxy_username": schema.StringAttribute{
+				MarkdownDescription: "Username used to authenticate against `proxy_url`",
+				Optional:            true,
+			},
+			"proxy_password": schema.StringAttribute{
+				MarkdownDescription: "Password used to authenticate against `proxy_url`",
+				Optional:            true,
+				Sensitive:           true,
+			},
+		},
+	}
 }
 
 func (p *JwkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var config JwkProviderModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tlsConfig, err := config.tlsConfig()
+	if err != nil {
+		resp.Diagnostics.AddError("tlsConfig", fmt.Sprintf("Can't configure TLS : %s", err))
+		return
+	}
+
+	retry, err := defaultRetryPolicy.withRetryOverrides(config.RetryAttempts, config.RetryMinBackoff, config.RetryMaxBackoff)
+	if err != nil {
+		resp.Diagnostics.AddError("withRetryOverrides", fmt.Sprintf("Can't configure retries : %s", err))
+		return
+	}
+
+	timeout, err := parseDuration(config.Timeout, 0)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("timeout"), "parseDuration", fmt.Sprintf("Invalid timeout : %s", err))
+		return
+	}
+
+	maxConcurrentRequests := int64(defaultMaxConcurrentRequests)
+	if !config.MaxConcurrentRequests.IsNull() {
+		maxConcurrentRequests = config.MaxConcurrentRequests.ValueInt64()
+	}
+	if maxConcurrentRequests < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_concurrent_requests"), "max_concurrent_requests", "Invalid max_concurrent_requests : must be at least 1")
+		return
+	}
+
+	proxy, err := config.proxy(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("proxy", fmt.Sprintf("Can't configure proxy : %s", err))
+		return
+	}
+
+	signatureAlgorithms, err := algorithmList(config.SignatureAlgorithms, supportedSignatureAlgorithms, nil)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("signature_algorithms"), "algorithmList", fmt.Sprintf("Invalid signature_algorithms : %s", err))
+		return
+	}
+	fipsMode := config.FipsMode.ValueBool()
+	supportedKeys, defaultKeys := supportedKeyAlgorithms, []jose.KeyAlgorithm(nil)
+	if fipsMode {
+		supportedKeys, defaultKeys = fipsKeyAlgorithms, fipsKeyAlgorithms
+	}
+	keyAlgorithms, err := algorithmList(config.KeyEncryptionAlgorithms, supportedKeys, defaultKeys)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("key_encryption_algorithms"), "algorithmList", fmt.Sprintf("Invalid key_encryption_algorithms : %s", err))
+		return
+	}
+	contentEncryptions, err := algorithmList(config.ContentEncryptionAlgorithms, supportedContentEncryptions, nil)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("content_encryption_algorithms"), "algorithmList", fmt.Sprintf("Invalid content_encryption_algorithms : %s", err))
+		return
+	}
+
+	base64url := config.Base64url.ValueString()
+	if base64url != "" && !slices.Contains(base64urlValues, base64url) {
+		resp.Diagnostics.AddAttributeError(path.Root("base64url"), "base64url", fmt.Sprintf("Unsupported base64url %q, expected passthrough, normalize or reject", base64url))
+		return
+	}
+
+	conformance := config.Conformance.ValueString()
+	if conformance != "" && !slices.Contains(conformanceValues, conformance) {
+		resp.Diagnostics.AddAttributeError(path.Root("conformance"), "conformance", fmt.Sprintf("Unsupported conformance %q, expected strict or lenient", conformance))
+		return
+	}
+
+	expiryWarningDays := int64(defaultExpiryWarningDays)
+	if !config.ExpiryWarningDays.IsNull() {
+		expiryWarningDays = config.ExpiryWarningDays.ValueInt64()
+	}
+	if expiryWarningDays < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("expiry_warning_days"), "expiry_warning_days", fmt.Sprintf("Invalid expiry_warning_days %d, expected 0 or a positive number of days", expiryWarningDays))
+		return
+	}
+
+	weakAlgorithms := config.WeakAlgorithms.ValueString()
+	if weakAlgorithms != "" && !slices.Contains(weakAlgorithmsValues, weakAlgorithms) {
+		resp.Diagnostics.AddAttributeError(path.Root("weak_algorithms"), "weak_algorithms", fmt.Sprintf("Unsupported weak_algorithms %q, expected warning, error or ignore", weakAlgorithms))
+		return
+	}
+
+	keyPolicy, err := config.keyPolicy()
+	if err != nil {
+		resp.Diagnostics.AddError("keyPolicy", fmt.Sprintf("Invalid key policy : %s", err))
+		return
+	}
+
+	data := &JwkProviderData{
+		allowedContentEncryptions:  contentEncryptions,
+		allowedKeyAlgorithms:       keyAlgorithms,
+		allowedSignatureAlgorithms: signatureAlgorithms,
+		base64url:                  base64url,
+		conformance:                conformance,
+		debugHttp:                  config.DebugHttp.ValueBool(),
+		disallowPrivateOutput:      config.DisallowPrivateOutput.ValueBool(),
+		expiryWarningWindow:        time.Duration(expiryWarningDays) * 24 * time.Hour,
+		fipsMode:                   fipsMode,
+		keyPolicy:                  keyPolicy,
+		offline:                    config.Offline.ValueBool(),
+		proxy:                      proxy,
+		requestSlots:               make(chan struct{}, maxConcurrentRequests),
+		retry:                      retry,
+		timeout:                    timeout,
+		tlsConfig:                  tlsConfig,
+		userAgent:                  p.userAgent(req.TerraformVersion, config.ExtraUserAgent),
+		version:                    p.version,
+		weakAlgorithms:             weakAlgorithms,
+	}
+	resp.DataSourceData = data
+	resp.EphemeralResourceData = data
+}
+
+// userAgent returns the User-Agent sent to remote endpoints, naming Terraform
+// and the provider followed by extra or TF_APPEND_USER_AGENT.
+func (p *JwkProvider) userAgent(terraformVersion string, extra types.String) string {
+	userAgent := fmt.Sprintf("Terraform/%s (+https://www.terraform.io) %s/%s", terraformVersion, defaultUserAgent, p.version)
+
+	extraUserAgent := extra.ValueString()
+	if extraUserAgent == "" {
+		extraUserAgent = os.Getenv("TF_APPEND_USER_AGENT")
+	}
+	if extraUserAgent = strings.TrimSpace(extraUserAgent); extraUserAgent != "" {
+		userAgent += " " + extraUserAgent
+	}
+	return userAgent
+}
+
+// keyPolicy returns the key policy enforced by the data sources.
+func (m JwkProviderModel) keyPolicy() (keyPolicy, error) {
+	var policy keyPolicy
+
+	if m.MinRsaBits.ValueInt64() < 0 {
+		return policy, fmt.Errorf("min_rsa_bits can't be negative")
+	}
+	policy.minRsaBits = int(m.MinRsaBits.ValueInt64())
+
+	for _, value := range m.AllowedCurves.Elements() {
+		curve := value.(types.String).ValueString()
+		if !slices.Contains(supportedCurves, curve) {
+			return policy, fmt.Errorf("unsupported curve %q in allowed_curves", curve)
+		}
+		policy.allowedCurves = append(policy.allowedCurves, curve)
+	}
+
+	for _, value := range m.BannedAlgorithms.Elements() {
+		alg := value.(types.String).ValueString()
+		if !knownAlgorithm(alg) {
+			return policy, fmt.Errorf("unsupported algorithm %q in banned_algorithms", alg)
+		}
+		policy.bannedAlgorithms = append(policy.bannedAlgorithms, alg)
+	}
+	return policy, nil
+}
+
+// tlsConfig returns the TLS defaults of the data sources, nil when none is
+// configured.
+func (m JwkProviderModel) tlsConfig() (*tls.Config, error) {
+	if m.CaBundle.IsNull() && m.ClientCertificate.IsNull() && m.ClientKey.IsNull() && m.TlsMinVersion.IsNull() {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{}
+	if caBundle := m.CaBundle.ValueString(); caBundle != "" {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM([]byte(caBundle)) {
+			return nil, fmt.Errorf("no certificate found in ca_bundle")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if m.ClientCertificate.ValueString() != "" || m.ClientKey.ValueString() != "" {
+		cert, err := tls.X509KeyPair([]byte(m.ClientCertificate.ValueString()), []byte(m.ClientKey.ValueString()))
+		if err != nil {
+			return nil, fmt.Errorf("can't load client certificate: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	switch version := m.TlsMinVersion.ValueString(); version {
+	case "", "1.2":
+		tlsConfig.MinVersion = tls.VersionTLS12
+	case "1.3":
+		tlsConfig.MinVersion = tls.VersionTLS13
+	default:
+		return nil, fmt.Errorf("unsupported tls_min_version %q, expected 1.2 or 1.3", version)
+	}
+
+	return tlsConfig, nil
+}
+
+// proxy returns the proxy selection function of the data sources, nil when
+// no proxy_url is configured.
+func (m JwkProviderModel) proxy(ctx context.Context) (func(*http.Request) (*url.URL, error), error) {
+	if m.ProxyUrl.ValueString() == "" {
+		if !m.NoProxy.IsNull() || !m.ProxyUsername.IsNull() || !m.ProxyPassword.IsNull() {
+			return nil, fmt.Errorf("no_proxy, proxy_username and proxy_password require proxy_url")
+		}
+		return nil, nil
+	}
+
+	proxyUrl, err := url.Parse(m.ProxyUrl.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy_url: %s", err)
+	}
+	if proxyUrl.Scheme == "" || proxyUrl.Host == "" {
+		return nil, fmt.Errorf("invalid proxy_url %q, expected an absolute URL", m.ProxyUrl.ValueString())
+	}
+	if username := m.ProxyUsername.ValueString(); username != "" {
+		proxyUrl.User = url.UserPassword(username, m.ProxyPassword.ValueString())
+	}
+
+	var noProxy []string
+	if diags := m.NoProxy.ElementsAs(ctx, &noProxy, false); diags.HasError() {
+		return nil, fmt.Errorf("invalid no_proxy")
+	}
+
+	config := httpproxy.Config{
+		HTTPProxy:  proxyUrl.String(),
+		HTTPSProxy: proxyUrl.String(),
+		NoProxy:    strings.Join(noProxy, ","),
+	}
+	proxyFunc := config.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}, nil
 }
 
 func (p *JwkProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -35,6 +446,76 @@ func (p *JwkProvider) DataSources(ctx context.Context) []func() datasource.DataS
 	return []func() datasource.DataSource{
 		NewJwkToPemDataSource,
 		NewJwkFromK8sDataSource,
+		NewJwkFromFileDataSource,
+		NewJwkFromEksDataSource,
+		NewJwkFromGkeDataSource,
+		NewJwkFromAksDataSource,
+		NewJwkFromSpiffeBundleDataSource,
+		NewJwkFromVaultDataSource,
+		NewJwkFromOktaDataSource,
+		NewJwkFromEntraIdDataSource,
+		NewJwkFromGoogleDataSource,
+		NewJwkFromGithubActionsDataSource,
+		NewJwkFromCircleciDataSource,
+		NewJwkFromCognitoDataSource,
+		NewJwkFromAppleDataSource,
+		NewJwkFromDexDataSource,
+		NewJwkFromFirebaseDataSource,
+		NewJwkOidcThumbprintsDataSource,
+		NewJwkFromK8sObjectDataSource,
+		NewJwkToK8sManifestDataSource,
+		NewJwkJwtSignDataSource,
+		NewJwkJwtVerifyDataSource,
+		NewJwkJwtDecodeDataSource,
+		NewJwkJweEncryptDataSource,
+		NewJwkJweDecryptDataSource,
+		NewJwkJwsDetachedSignDataSource,
+		NewJwkJwsDetachedVerifyDataSource,
+		NewJwkJwtSignAwsKmsDataSource,
+		NewJwkJwtSignGcpKmsDataSource,
+		NewJwkJwtSignAzureKeyVaultDataSource,
+		NewJwkJwtSignVaultTransitDataSource,
+		NewJwkK8sServiceAccountTokenDataSource,
+		NewJwkOidcIdTokenVerifyDataSource,
+		NewJwkJwsSignDataSource,
+		NewJwkJwsVerifyDataSource,
+		NewJwkConfirmationDataSource,
+		NewJwkSdJwtSignDataSource,
+		NewJwkProviderInfoDataSource,
+		NewJwkValidateDataSource,
+		NewJwkPolicyCheckDataSource,
+		NewJwkAssertPublicOnlyDataSource,
+		NewJwkKidCollisionsDataSource,
+		NewJwkSecurityStrengthDataSource,
+		NewJwkAuditReportDataSource,
+	}
+}
+
+func (p *JwkProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewJwkTestTokenEphemeralResource,
+	}
+}
+
+func (p *JwkProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewJwkThumbprintFunction,
+		NewJwkToPemFunction,
+		NewJwkFromPemFunction,
+		NewJwkJwksFilterFunction,
+		NewJwkDecodeJwtFunction,
+		NewJwkValidateFunction,
+		NewJwkValidateErrorFunction,
+		NewJwkKidFunction,
+		NewJwkB64urlEncodeFunction,
+		NewJwkB64urlDecodeFunction,
+		NewJwkPublicFunction,
+		NewJwkMergeJwksFunction,
+		NewJwkToSshFunction,
+		NewJwkCanonicalizeFunction,
+		NewJwkX5tFunction,
+		NewJwkDidJwkFunction,
+		NewJwkAlgForKeyFunction,
 	}
 }
 
@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// JwkProviderData holds the provider configuration shared with the data
+// sources through Configure.
+type JwkProviderData struct {
+	allowedContentEncryptions  []jose.ContentEncryption
+	allowedKeyAlgorithms       []jose.KeyAlgorithm
+	allowedSignatureAlgorithms []jose.SignatureAlgorithm
+	base64url                  string
+	conformance                string
+	debugHttp                  bool
+	disallowPrivateOutput      bool
+	expiryWarningWindow        time.Duration
+	fipsMode                   bool
+	keyPolicy                  keyPolicy
+	offline                    bool
+	proxy                      func(*http.Request) (*url.URL, error)
+	requestSlots               chan struct{}
+	retry                      retryPolicy
+	timeout                    time.Duration
+	tlsConfig                  *tls.Config
+	userAgent                  string
+	version                    string
+	weakAlgorithms             string
+}
+
+// defaultUserAgent is sent to remote endpoints until the provider is
+// configured.
+const defaultUserAgent = "terraform-provider-jwk"
+
+// configuredProviderData returns the provider data passed to Configure, nil
+// until the provider is configured.
+func configuredProviderData(providerData any, diags *diag.Diagnostics) *JwkProviderData {
+	if providerData == nil {
+		return nil
+	}
+
+	data, ok := providerData.(*JwkProviderData)
+	if !ok {
+		diags.AddError("ProviderData", "Unexpected provider data, this is a bug in the provider")
+		return nil
+	}
+	return data
+}
+
+// withTlsDefaults returns a copy of tlsConfig completed with the provider TLS
+// defaults. The settings of tlsConfig take precedence.
+func (p *JwkProviderData) withTlsDefaults(tlsConfig *tls.Config) *tls.Config {
+	if p == nil || p.tlsConfig == nil {
+		return tlsConfig
+	}
+	if tlsConfig == nil {
+		return p.tlsConfig.Clone()
+	}
+
+	tlsConfig = tlsConfig.Clone()
+	if tlsConfig.RootCAs == nil {
+		tlsConfig.RootCAs = p.tlsConfig.RootCAs
+	}
+	if len(tlsConfig.Certificates) == 0 {
+		tlsConfig.Certificates = p.tlsConfig.Certificates
+	}
+	if tlsConfig.MinVersion == 0 {
+		tlsConfig.MinVersion = p.tlsConfig.MinVersion
+	}
+	return tlsConfig
+}
+
+// errOffline is returned instead of reaching a remote endpoint when the
+// provider is offline.
+var errOffline = errors.New("network access is disabled by the provider offline option")
+
+// checkOnline returns errOffline when the provider is offline.
+func (p *JwkProviderData) checkOnline() error {
+	if p != nil && p.offline {
+		return errOffline
+	}
+	return nil
+}
+
+// newHTTPClient returns the HTTP client used to reach remote endpoints,
+// using tlsConfig completed with the provider TLS defaults, going through the
+// provider proxy, or the one of the environment, sending the provider
+// User-Agent and retrying according to the provider retry defaults. Every
+// attempt is logged. Every request of the client fails when the provider is
+// offline, or is answered by the mock server when TF_JWK_MOCK_FIXTURES is
+// set.
+func (p *JwkProviderData) newHTTPClient(tlsConfig *tls.Config) *http.Client {
+	if p.checkOnline() != nil && os.Getenv(mockFixturesEnv) == "" {
+		return &http.Client{Transport: offlineTransport{}}
+	}
+
+	proxy, policy, timeout, userAgent := http.ProxyFromEnvironment, defaultRetryPolicy, time.Duration(0), defaultUserAgent
+	if p != nil {
+		policy, timeout = p.retry, p.timeout
+		if p.userAgent != "" {
+			userAgent = p.userAgent
+		}
+		if p.proxy != nil {
+			proxy = p.proxy
+		}
+	}
+
+	var base http.RoundTripper = &http.Transport{Proxy: proxy, TLSClientConfig: p.withTlsDefaults(tlsConfig)}
+	if mock := newMockTransport(); mock != nil {
+		base = mock
+	}
+	var transport http.RoundTripper = &logTransport{base: base, trace: p != nil && p.debugHttp}
+	if p != nil && p.requestSlots != nil {
+		transport = &limitTransport{base: transport, slots: p.requestSlots}
+	}
+	return &http.Client{
+		Transport: &headerTransport{
+			base:    &retryTransport{base: transport, policy: policy},
+			headers: map[string]string{"User-Agent": userAgent},
+		},
+		Timeout: timeout,
+	}
+}
+
+// offlineTransport fails every request with errOffline.
+type offlineTransport struct{}
+
+func (offlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, errOffline
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// newTestProviderServer returns a configured provider server along with its
+// schemas.
+func newTestProviderServer(t *testing.T) (tfprotov6.ProviderServer, *tfprotov6.GetProviderSchemaResponse) {
+	t.Helper()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiagnostics(t, schemas.Diagnostics)
+
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config:           testConfig(t, schemas.Provider, nil),
+		TerraformVersion: "1.9.0",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiagnostics(t, configureResp.Diagnostics)
+
+	return server, schemas
+}
+
+// testConfig returns the configuration of schema setting values, leaving the
+// other attributes and blocks null.
+func testConfig(t *testing.T, schema *tfprotov6.Schema, values map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	objectType := schema.ValueType().(tftypes.Object)
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		}
+	}
+
+	config, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, attributes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &config
+}
+
+// readDataSource reads the typeName data source configured with values,
+// returning its state attributes and diagnostics.
+func readDataSource(t *testing.T, server tfprotov6.ProviderServer, schemas *tfprotov6.GetProviderSchemaResponse, typeName string, values map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic) {
+	t.Helper()
+
+	schema := schemas.DataSourceSchemas[typeName]
+	if schema == nil {
+		t.Fatalf("no %s data source", typeName)
+	}
+	resp, err := server.ReadDataSource(context.Background(), &tfprotov6.ReadDataSourceRequest{
+		Config:   testConfig(t, schema, values),
+		TypeName: typeName,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.State == nil {
+		return nil, resp.Diagnostics
+	}
+
+	state, err := resp.State.Unmarshal(schema.ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var attributes map[string]tftypes.Value
+	if err := state.As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+	return attributes, resp.Diagnostics
+}
+
+func checkDiagnostics(t *testing.T, diags []*tfprotov6.Diagnostic) {
+	t.Helper()
+	for _, diag := range diags {
+		if diag.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("%s: %s", diag.Summary, diag.Detail)
+		}
+	}
+}
+
+func stringValue(t *testing.T, value tftypes.Value) string {
+	t.Helper()
+	var s string
+	if err := value.As(&s); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func stringListValue(t *testing.T, value tftypes.Value) []string {
+	t.Helper()
+	var elements []tftypes.Value
+	if err := value.As(&elements); err != nil {
+		t.Fatal(err)
+	}
+	var list []string
+	for _, element := range elements {
+		list = append(list, stringValue(t, element))
+	}
+	return list
+}
+
+// recordingServer is a test server keeping the headers of the requests it
+// served.
+type recordingServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	headers []http.Header
+}
+
+func newRecordingServer(t *testing.T, handler http.HandlerFunc) *recordingServer {
+	t.Helper()
+	server := &recordingServer{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.mu.Lock()
+		server.headers = append(server.headers, r.Header.Clone())
+		server.mu.Unlock()
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// localhostUrl returns the URL of the server on localhost, a host other than
+// the 127.0.0.1 of its URL for the HTTP client.
+func (s *recordingServer) localhostUrl() string {
+	return strings.Replace(s.URL, "127.0.0.1", "localhost", 1)
+}
+
+// received returns the values of the name header of the served requests.
+func (s *recordingServer) received(name string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var values []string
+	for _, header := range s.headers {
+		if value := header.Get(name); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// requests returns the number of served requests.
+func (s *recordingServer) requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.headers)
+}
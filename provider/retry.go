@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultRetryMinBackoff = time.Second
+	defaultRetryMaxBackoff = 30 * time.Second
+)
+
+// retryPolicy tells how many times and how long apart failed requests are
+// retried.
+type retryPolicy struct {
+	attempts   int64
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+var defaultRetryPolicy = retryPolicy{minBackoff: defaultRetryMinBackoff, maxBackoff: defaultRetryMaxBackoff}
+
+// withRetryOverrides returns a copy of p with the set attributes.
+func (p retryPolicy) withRetryOverrides(attempts types.Int64, minBackoff, maxBackoff types.String) (retryPolicy, error) {
+	var err error
+	if !attempts.IsNull() {
+		if attempts.ValueInt64() < 0 {
+			return p, fmt.Errorf("retry_attempts can't be negative")
+		}
+		p.attempts = attempts.ValueInt64()
+	}
+	if p.minBackoff, err = parseDuration(minBackoff, p.minBackoff); err != nil {
+		return p, fmt.Errorf("invalid retry_min_backoff: %s", err)
+	}
+	if p.maxBackoff, err = parseDuration(maxBackoff, p.maxBackoff); err != nil {
+		return p, fmt.Errorf("invalid retry_max_backoff: %s", err)
+	}
+	return p, nil
+}
+
+// backoff returns the delay before the retry following attempt, doubling
+// from minBackoff up to maxBackoff. A Retry-After header in seconds takes
+// precedence, within maxBackoff.
+func (p retryPolicy) backoff(attempt int64, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, p.maxBackoff)
+		}
+	}
+
+	delay := p.minBackoff
+	for i := int64(0); i < attempt && delay < p.maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, p.maxBackoff)
+}
+
+// retryTransport retries the idempotent requests sent through base that fail
+// or are answered with a throttling or transient server error.
+type retryTransport struct {
+	base   http.RoundTripper
+	policy retryPolicy
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return t.base.RoundTrip(req)
+	}
+
+	for attempt := int64(0); ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if attempt >= t.policy.attempts || req.Context().Err() != nil || !retryable(resp, err) {
+			return resp, err
+		}
+
+		delay := t.policy.backoff(attempt, resp)
+		tflog.Debug(withRedaction(req.Context()), "Retrying HTTP request", map[string]any{
+			"url":     logUrl(req.URL),
+			"attempt": attempt + 1,
+			"delay":   delay.String(),
+		})
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// retryable reports whether a request answered with resp or failing with err
+// is worth retrying.
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
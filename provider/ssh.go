@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// sshTunnel dials connections from a bastion host, authenticating with the
+// SSH agent listening on agentSocket when set.
+type sshTunnel struct {
+	addr        string
+	agentSocket string
+	config      *ssh.ClientConfig
+	dial        func(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+// sshConn is a connection tunnelled through client, which is closed along
+// with it.
+type sshConn struct {
+	net.Conn
+	client *ssh.Client
+}
+
+func (c *sshConn) Close() error {
+	err := c.Conn.Close()
+	if clientErr := c.client.Close(); err == nil {
+		err = clientErr
+	}
+	return err
+}
+
+// sshTunnel returns the tunnel configured by the ssh_* attributes, connecting
+// to the bastion with dial.
+func (m FetchOptionsModel) sshTunnel(dial func(ctx context.Context, network, addr string) (net.Conn, error)) (*sshTunnel, error) {
+	user := m.SshUser.ValueString()
+	if user == "" {
+		user = os.Getenv("USER")
+	}
+
+	var auth []ssh.AuthMethod
+	var agentSocket string
+	if privateKey := m.SshPrivateKey.ValueString(); privateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(privateKey))
+		if err != nil {
+			return nil, fmt.Errorf("can't parse ssh_private_key: %s", err)
+		}
+		auth = []ssh.AuthMethod{ssh.PublicKeys(signer)}
+	} else if socket := os.Getenv("SSH_AUTH_SOCK"); socket != "" {
+		agentSocket = socket
+	} else {
+		return nil, fmt.Errorf("no ssh_private_key configured and no SSH agent running")
+	}
+
+	var hostKeyCallback ssh.HostKeyCallback
+	if hostKey := m.SshHostKey.ValueString(); hostKey != "" {
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hostKey))
+		if err != nil {
+			return nil, fmt.Errorf("can't parse ssh_host_key: %s", err)
+		}
+		hostKeyCallback = ssh.FixedHostKey(key)
+	} else {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		hostKeyCallback, err = knownhosts.New(filepath.Join(home, ".ssh", "known_hosts"))
+		if err != nil {
+			return nil, fmt.Errorf("no ssh_host_key configured: %s", err)
+		}
+	}
+
+	return &sshTunnel{
+		addr:        withDefaultPort(m.SshHost.ValueString(), ":22"),
+		agentSocket: agentSocket,
+		config: &ssh.ClientConfig{
+			User:            user,
+			Auth:            auth,
+			HostKeyCallback: hostKeyCallback,
+		},
+		dial: dial,
+	}, nil
+}
+
+// DialContext connects to the bastion and dials addr from it.
+func (t *sshTunnel) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	config := t.config
+	if t.agentSocket != "" {
+		// The agent is only needed to sign the handshake, its connection is
+		// closed once authenticated.
+		agentConn, err := net.Dial("unix", t.agentSocket)
+		if err != nil {
+			return nil, fmt.Errorf("can't connect to the SSH agent: %s", err)
+		}
+		defer agentConn.Close()
+
+		agentConfig := *t.config
+		agentConfig.Auth = []ssh.AuthMethod{ssh.PublicKeysCallback(agent.NewClient(agentConn).Signers)}
+		config = &agentConfig
+	}
+
+	conn, err := t.dial(ctx, "tcp", t.addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// The SSH handshake doesn't take a context, closing the connection aborts
+	// it on cancellation.
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	sshConnection, chans, reqs, err := ssh.NewClientConn(conn, t.addr, config)
+	if !stop() {
+		if err == nil {
+			sshConnection.Close()
+		}
+		return nil, fmt.Errorf("can't connect to %s: %s", t.addr, ctx.Err())
+	}
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("can't connect to %s: %s", t.addr, err)
+	}
+	client := ssh.NewClient(sshConnection, chans, reqs)
+
+	remoteConn, err := client.DialContext(ctx, network, addr)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("can't dial %s from %s: %s", addr, t.addr, err)
+	}
+
+	return &sshConn{Conn: remoteConn, client: client}, nil
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+)
+
+// rsaStrengths are the security strengths of RSA keys by minimum size, per
+// NIST SP 800-57 Part 1 table 2.
+var rsaStrengths = []struct {
+	bits     int
+	strength int
+}{
+	{15360, 256},
+	{7680, 192},
+	{3072, 128},
+	{2048, 112},
+	{1024, 80},
+}
+
+// keyStrength returns the size in bits of key and its estimated security
+// strength in bits per NIST SP 800-57, ok being false for unknown keys.
+func keyStrength(key any) (bits int, strength int, ok bool) {
+	switch k := publicKey(key).(type) {
+	case *rsa.PublicKey:
+		bits = k.N.BitLen()
+		for _, s := range rsaStrengths {
+			if bits >= s.bits {
+				return bits, s.strength, true
+			}
+		}
+		return bits, 0, true
+	case *ecdsa.PublicKey:
+		bits = k.Curve.Params().BitSize
+		return bits, bits / 2, true
+	case ed25519.PublicKey:
+		return 256, 128, true
+	case []byte:
+		bits = len(k) * 8
+		return bits, min(bits, 256), true
+	default:
+		return 0, 0, false
+	}
+}
+
+// strengthLevels are the security strengths of NIST SP 800-57 Part 1 table 2,
+// strongest first.
+var strengthLevels = []int{256, 192, 128, 112, 80}
+
+const (
+	strengthAcceptable          = "acceptable"
+	strengthAcceptableUntil2030 = "acceptable_until_2030"
+	strengthDisallowed          = "disallowed"
+)
+
+// strengthLevel returns the NIST SP 800-57 security strength level strength
+// reaches, 0 below 80 bits.
+func strengthLevel(strength int) int {
+	for _, level := range strengthLevels {
+		if strength >= level {
+			return level
+		}
+	}
+	return 0
+}
+
+// strengthStatus returns the NIST SP 800-131A status of a security strength
+// level for protecting new data.
+func strengthStatus(level int) string {
+	switch {
+	case level >= 128:
+		return strengthAcceptable
+	case level >= 112:
+		return strengthAcceptableUntil2030
+	default:
+		return strengthDisallowed
+	}
+}
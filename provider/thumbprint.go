@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+)
+
+// caThumbprints connects to the host of rawUrl with the TLS defaults of p and
+// returns the SHA-1 thumbprints of the CA certificates it presents, the top of
+// the chain first, as expected by AWS IAM OIDC providers.
+func caThumbprints(ctx context.Context, rawUrl string, p *JwkProviderData) ([]string, error) {
+	if err := p.checkOnline(); err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = "443"
+	}
+
+	tlsConfig := p.withTlsDefaults(nil)
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+	tlsConfig = tlsConfig.Clone()
+	tlsConfig.ServerName = u.Hostname()
+
+	dialer := &tls.Dialer{Config: tlsConfig}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(u.Hostname(), port))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(certs) < 2 {
+		return nil, fmt.Errorf("%s presents no CA certificate", u.Host)
+	}
+
+	var thumbprints []string
+	for i := len(certs) - 1; i > 0; i-- {
+		sum := sha1.Sum(certs[i].Raw)
+		thumbprints = append(thumbprints, hex.EncodeToString(sum[:]))
+	}
+	return thumbprints, nil
+}
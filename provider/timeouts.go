@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	ephemeraltimeouts "github.com/hashicorp/terraform-plugin-framework-timeouts/ephemeral/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// defaultReadTimeout bounds the reads of the data sources and the opening of
+// the ephemeral resources without a timeout in their timeouts block.
+const defaultReadTimeout = 20 * time.Minute
+
+// readContext returns ctx bounded by the read timeout of the timeouts block.
+func readContext(ctx context.Context, value timeouts.Value, diags *diag.Diagnostics) (context.Context, context.CancelFunc) {
+	readTimeout, timeoutsDiags := value.Read(ctx, defaultReadTimeout)
+	diags.Append(timeoutsDiags...)
+	return context.WithTimeout(ctx, readTimeout)
+}
+
+// openContext returns ctx bounded by the open timeout of the timeouts block.
+func openContext(ctx context.Context, value ephemeraltimeouts.Value, diags *diag.Diagnostics) (context.Context, context.CancelFunc) {
+	openTimeout, timeoutsDiags := value.Open(ctx, defaultReadTimeout)
+	diags.Append(timeoutsDiags...)
+	return context.WithTimeout(ctx, openTimeout)
+}
@@ -0,0 +1,283 @@
+package provider
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+// jwkRequiredMembers are the members RFC 7518 and RFC 8037 require for each
+// key type.
+var jwkRequiredMembers = map[string][]string{
+	"EC":  {"crv", "x", "y"},
+	"OKP": {"crv", "x"},
+	"RSA": {"e", "n"},
+	"oct": {"k"},
+}
+
+// rsaPrimeMembers are the members RSA private keys hold along with d, all of
+// them or none (RFC 7518 section 6.3.2).
+var rsaPrimeMembers = []string{"dp", "dq", "p", "q", "qi"}
+
+// jwkReport holds the problems found in a JWK, errors making it unusable,
+// along with its x5c certificate chain and its RFC 7638 thumbprint.
+type jwkReport struct {
+	certificates []*x509.Certificate
+	errors       []string
+	thumbprint   string
+	warnings     []string
+}
+
+func (r *jwkReport) errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *jwkReport) warnf(format string, args ...any) {
+	r.warnings = append(r.warnings, fmt.Sprintf(format, args...))
+}
+
+// validateJwk checks the structure, the members and the key material of
+// data, a single JWK, along with the FIPS rules when fips is set.
+func validateJwk(data string, fips bool) jwkReport {
+	var report jwkReport
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(data), &members); err != nil {
+		report.errorf("the JWK isn't a JSON object: %s", err)
+		return report
+	}
+
+	var kty string
+	if err := json.Unmarshal(members["kty"], &kty); err != nil || kty == "" {
+		report.errorf("the kty member is missing or isn't a string")
+		return report
+	}
+	required, ok := jwkRequiredMembers[kty]
+	if !ok {
+		report.errorf("unsupported key type %q, expected EC, OKP, RSA or oct", kty)
+		return report
+	}
+	for _, name := range required {
+		if _, ok := members[name]; !ok {
+			report.errorf("the %s member is required for %s keys", name, kty)
+		}
+	}
+
+	decoded := map[string][]byte{}
+	for _, name := range jwkBase64urlMembers {
+		raw, ok := members[name]
+		if !ok {
+			continue
+		}
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			report.errorf("the %s member isn't a string", name)
+			continue
+		}
+		bytes, err := base64.RawURLEncoding.Strict().DecodeString(value)
+		if err != nil {
+			if _, canonicalErr := canonicalBase64url(value); canonicalErr == nil {
+				report.errorf("the %s member isn't canonical base64url: padding, standard alphabet or whitespace", name)
+			} else {
+				report.errorf("the %s member isn't base64url: %s", name, err)
+			}
+			continue
+		}
+		decoded[name] = bytes
+	}
+
+	for _, name := range []string{"alg", "kid", "use"} {
+		if raw, ok := members[name]; ok {
+			var value string
+			if err := json.Unmarshal(raw, &value); err != nil {
+				report.errorf("the %s member isn't a string", name)
+			}
+		}
+	}
+
+	if kty == "RSA" {
+		if _, ok := members["d"]; ok {
+			present := 0
+			for _, name := range rsaPrimeMembers {
+				if _, ok := members[name]; ok {
+					present++
+				}
+			}
+			if present != 0 && present != len(rsaPrimeMembers) {
+				report.errorf("RSA private keys must hold all of %s or none of them", strings.Join(rsaPrimeMembers, ", "))
+			}
+		}
+		for _, name := range []string{"e", "n"} {
+			if bytes := decoded[name]; len(bytes) > 1 && bytes[0] == 0 {
+				report.errorf("the %s member has leading zero octets", name)
+			}
+		}
+		if e, ok := decoded["e"]; ok {
+			if exponent := new(big.Int).SetBytes(e); exponent.Bit(0) == 0 || exponent.Cmp(big.NewInt(3)) < 0 {
+				report.errorf("the public exponent %s must be odd and at least 3", exponent)
+			}
+		}
+	}
+	if _, ok := members["x5c"]; ok && len(report.errors) == 0 {
+		report.checkCertificate(members, decoded)
+	}
+	if len(report.errors) > 0 {
+		return report
+	}
+
+	jwk, err := jwkutil.ParseJwk(data)
+	if err != nil {
+		report.errorf("can't parse the JWK: %s", err)
+		return report
+	}
+	if !validKey(jwk) {
+		report.errorf("invalid key material")
+		return report
+	}
+
+	report.certificates = jwk.Certificates
+	if err := checkAlgorithmKey(jwk, jwk.Algorithm); err != nil {
+		report.errorf("%s", err)
+	}
+	if thumbprint, err := jwkutil.Thumbprint(jwk, crypto.SHA256); err == nil {
+		report.thumbprint = base64.RawURLEncoding.EncodeToString(thumbprint)
+	}
+
+	switch key := jwk.Key.(type) {
+	case *rsa.PrivateKey:
+		if err := key.Validate(); err != nil {
+			report.errorf("inconsistent RSA private key: %s", err)
+		}
+	case *ecdsa.PrivateKey, *ecdsa.PublicKey:
+		params := publicKey(key).(*ecdsa.PublicKey).Curve.Params()
+		size := (params.BitSize + 7) / 8
+		for _, name := range []string{"d", "x", "y"} {
+			if bytes, ok := decoded[name]; ok && len(bytes) != size {
+				report.errorf("the %s member is %d octets long, %d expected for the %s curve", name, len(bytes), size, params.Name)
+			}
+		}
+	}
+
+	if key, ok := publicKey(jwk.Key).(*rsa.PublicKey); ok {
+		report.checkCompromisedRsa(key)
+	}
+
+	if fips {
+		if err := checkFipsKey(jwk.Key, "fips"); err != nil {
+			report.errorf("%s", err)
+		}
+		if err := checkFipsAlgorithm(jwk.Algorithm, "fips"); err != nil {
+			report.errorf("%s", err)
+		}
+	}
+
+	if reason := weakKeyReason(jwk, ""); reason != "" {
+		report.warnf("%s", reason)
+	}
+	if jwk.KeyID == "" {
+		report.warnf("the JWK has no kid, verifiers can't select it in a JWKS")
+	}
+	if jwk.Use != "" && !slices.Contains([]string{"enc", "sig"}, jwk.Use) {
+		report.warnf("unknown public key use %q, expected sig or enc", jwk.Use)
+	}
+
+	return report
+}
+
+// checkCertificateValidity adds an error to report when the leaf certificate
+// of its chain isn't valid at now.
+func (r *jwkReport) checkCertificateValidity(now time.Time) {
+	if len(r.certificates) == 0 {
+		return
+	}
+	cert := r.certificates[0]
+	if now.Before(cert.NotBefore) {
+		r.errorf("the x5c certificate isn't valid before %s", cert.NotBefore.UTC().Format(time.RFC3339))
+	}
+	if now.After(cert.NotAfter) {
+		r.errorf("the x5c certificate expired on %s", cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+}
+
+// verifyCertificateChain returns an error when the x5c chain of r doesn't
+// lead to one of roots at now.
+func (r *jwkReport) verifyCertificateChain(roots *x509.CertPool, now time.Time) error {
+	if len(r.certificates) == 0 {
+		return fmt.Errorf("the JWK has no x5c certificate chain")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range r.certificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := r.certificates[0].Verify(x509.VerifyOptions{
+		CurrentTime:   now,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		Roots:         roots,
+	})
+	return err
+}
+
+// checkCertificate adds an error to r when the key of the JWK members, or its
+// x5t and x5t#S256 members, decoded in decoded, aren't the ones of the leaf
+// certificate of its x5c chain, the JWK and the certificate having drifted
+// apart.
+func (r *jwkReport) checkCertificate(members map[string]json.RawMessage, decoded map[string][]byte) {
+	var chain []string
+	if err := json.Unmarshal(members["x5c"], &chain); err != nil || len(chain) == 0 {
+		r.errorf("the x5c member isn't a list of certificates")
+		return
+	}
+	der, err := base64.StdEncoding.DecodeString(chain[0])
+	if err != nil {
+		r.errorf("the x5c leaf certificate isn't base64: %s", err)
+		return
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		r.errorf("can't parse the x5c leaf certificate: %s", err)
+		return
+	}
+
+	sha1Sum := sha1.Sum(der)
+	sha256Sum := sha256.Sum256(der)
+	if x5t, ok := decoded["x5t"]; ok && !bytes.Equal(x5t, sha1Sum[:]) {
+		r.errorf("the x5t member isn't the thumbprint of the x5c leaf certificate, %s expected", base64.RawURLEncoding.EncodeToString(sha1Sum[:]))
+	}
+	if x5tS256, ok := decoded["x5t#S256"]; ok && !bytes.Equal(x5tS256, sha256Sum[:]) {
+		r.errorf("the x5t#S256 member isn't the thumbprint of the x5c leaf certificate, %s expected", base64.RawURLEncoding.EncodeToString(sha256Sum[:]))
+	}
+
+	keyMembers := map[string]json.RawMessage{}
+	for name, value := range members {
+		if !slices.Contains([]string{"x5c", "x5t", "x5t#S256"}, name) {
+			keyMembers[name] = value
+		}
+	}
+	keyData, err := json.Marshal(keyMembers)
+	if err != nil {
+		return
+	}
+	jwk, err := jwkutil.ParseJwk(string(keyData))
+	if err != nil {
+		return
+	}
+	key, ok := publicKey(jwk.Key).(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !key.Equal(leaf.PublicKey) {
+		r.errorf("the key of the JWK isn't the one of its x5c leaf certificate")
+	}
+}
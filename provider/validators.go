@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+var _ validator.String = jwkValidator{}
+var _ validator.List = jwkListValidator{}
+var _ validator.String = pemValidator{}
+
+// jwkValidator checks a string attribute holds a JWK, or a JWKS when jwks is
+// set. The keys must be private or symmetric when private is set.
+type jwkValidator struct {
+	jwks    bool
+	private bool
+}
+
+func (v jwkValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v jwkValidator) MarkdownDescription(ctx context.Context) string {
+	switch {
+	case v.jwks && v.private:
+		return "value must be a private JWK or a JWKS of private JWKs"
+	case v.jwks:
+		return "value must be a JWK or a JWKS"
+	case v.private:
+		return "value must be a private JWK"
+	default:
+		return "value must be a JWK"
+	}
+}
+
+func (v jwkValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !v.jwks {
+		if err := checkJwk(req.ConfigValue.ValueString(), v.private); err != nil {
+			resp.Diagnostics.AddAttributeError(req.Path, "Invalid JWK", withJwkHint(fmt.Sprintf("Can't parse JWK : %s", err), req.ConfigValue.ValueString(), false))
+		}
+		return
+	}
+
+	keys, err := jwkutil.ParseJwks([]byte(req.ConfigValue.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid JWK", withJwkHint(fmt.Sprintf("Can't parse JWK or JWKS : %s", err), req.ConfigValue.ValueString(), true))
+		return
+	}
+	for i, key := range keys {
+		if err := checkJwk(string(key), v.private); err != nil {
+			resp.Diagnostics.AddAttributeError(req.Path, "Invalid JWK", fmt.Sprintf("Can't parse key #%d : %s", i, err))
+		}
+	}
+}
+
+// jwkListValidator checks every element of a list attribute holds a JWK,
+// private or symmetric when private is set.
+type jwkListValidator struct {
+	private bool
+}
+
+func (v jwkListValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v jwkListValidator) MarkdownDescription(ctx context.Context) string {
+	if v.private {
+		return "every element must be a private JWK"
+	}
+	return "every element must be a JWK"
+}
+
+func (v jwkListValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for i, value := range req.ConfigValue.Elements() {
+		key, ok := value.(types.String)
+		if !ok || key.IsNull() || key.IsUnknown() {
+			continue
+		}
+		if err := checkJwk(key.ValueString(), v.private); err != nil {
+			resp.Diagnostics.AddAttributeError(req.Path.AtListIndex(i), "Invalid JWK", withJwkHint(fmt.Sprintf("Can't parse JWK : %s", err), key.ValueString(), false))
+		}
+	}
+}
+
+// validKey reports whether jwk holds usable key material. go-jose doesn't
+// validate symmetric keys, which are only required to be non empty.
+func validKey(jwk jose.JSONWebKey) bool {
+	if key, ok := jwk.Key.([]byte); ok {
+		return len(key) > 0
+	}
+	return jwk.Valid()
+}
+
+// checkJwk parses data as a JWK holding valid key material, private or
+// symmetric when private is set. Non canonical base64url members are
+// accepted, the provider base64url setting deciding what to do with them.
+func checkJwk(data string, private bool) error {
+	if normalized, _, err := normalizeBase64url(json.RawMessage(data)); err == nil {
+		data = string(normalized)
+	}
+	jwk, err := jwkutil.ParseJwk(data)
+	if err != nil {
+		return err
+	}
+	if !validKey(jwk) {
+		return fmt.Errorf("invalid key material")
+	}
+	if private && jwk.IsPublic() {
+		return fmt.Errorf("public key found, a private key is expected")
+	}
+	return nil
+}
+
+// pemValidator checks a string attribute holds PEM encoded certificates, or
+// a single private key when privateKey is set.
+type pemValidator struct {
+	privateKey bool
+}
+
+func (v pemValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v pemValidator) MarkdownDescription(ctx context.Context) string {
+	if v.privateKey {
+		return "value must be a PEM encoded private key"
+	}
+	return "value must be PEM encoded certificates"
+}
+
+func (v pemValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || req.ConfigValue.ValueString() == "" {
+		return
+	}
+
+	var err error
+	if v.privateKey {
+		err = checkPemPrivateKey([]byte(req.ConfigValue.ValueString()))
+	} else {
+		err = checkPemCertificates([]byte(req.ConfigValue.ValueString()))
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid PEM", fmt.Sprintf("Can't parse PEM : %s", err))
+	}
+}
+
+// checkPemCertificates parses the certificate PEM blocks of data, the other
+// blocks are ignored like crypto/tls and crypto/x509 do.
+func checkPemCertificates(data []byte) error {
+	count := 0
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return fmt.Errorf("can't parse certificate #%d: %s", count, err)
+		}
+		count++
+	}
+
+	if count == 0 {
+		return fmt.Errorf("no certificate found")
+	}
+	return nil
+}
+
+// checkPemPrivateKey parses the first PEM block of data as a private key.
+func checkPemPrivateKey(data []byte) error {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return fmt.Errorf("no PEM block found")
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY", "RSA PRIVATE KEY", "EC PRIVATE KEY":
+	default:
+		return fmt.Errorf("unexpected PEM block type %q, expected a private key", block.Type)
+	}
+
+	jwk, err := jwkutil.PemBlockToJwk(block)
+	if err != nil {
+		return err
+	}
+	if !validKey(jwk) {
+		return fmt.Errorf("invalid key material")
+	}
+	return nil
+}
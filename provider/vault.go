@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// vaultConfig resolves the Vault address and the headers authenticating the
+// requests from the attributes, falling back to the Vault CLI environment.
+func vaultConfig(address, token, namespace types.String) (string, map[string]string, error) {
+	addr := strings.TrimRight(valueOrEnv(address, "VAULT_ADDR"), "/")
+	if addr == "" {
+		return "", nil, fmt.Errorf("no Vault address configured")
+	}
+
+	headers := map[string]string{}
+	if token := valueOrEnv(token, "VAULT_TOKEN"); token != "" {
+		headers["X-Vault-Token"] = token
+	}
+	if namespace := valueOrEnv(namespace, "VAULT_NAMESPACE"); namespace != "" {
+		headers["X-Vault-Namespace"] = namespace
+	}
+	return addr, headers, nil
+}
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jjacobelli/terraform-provider-jwk/pkg/jwkutil"
+)
+
+const (
+	weakMinRsaBits = 2048
+
+	weakAlgorithmsWarning = "warning"
+	weakAlgorithmsError   = "error"
+	weakAlgorithmsIgnore  = "ignore"
+)
+
+var weakAlgorithmsValues = []string{weakAlgorithmsWarning, weakAlgorithmsError, weakAlgorithmsIgnore}
+
+// hmacMinBits are the key sizes RFC 7518 requires for the HMAC algorithms.
+var hmacMinBits = map[string]int{
+	string(jose.HS256): 256,
+	string(jose.HS384): 384,
+	string(jose.HS512): 512,
+}
+
+// weakKeyReason returns why using jwk with alg is weak, empty when it isn't.
+func weakKeyReason(jwk jose.JSONWebKey, alg string) string {
+	if alg == "" {
+		alg = jwk.Algorithm
+	}
+
+	switch alg {
+	case "none":
+		return "the none algorithm leaves tokens unsigned"
+	case string(jose.RSA1_5):
+		return "the RSA1_5 key management algorithm is deprecated, use RSA-OAEP-256"
+	}
+
+	switch key := publicKey(jwk.Key).(type) {
+	case *rsa.PublicKey:
+		if bits := key.N.BitLen(); bits < weakMinRsaBits {
+			return fmt.Sprintf("%d bits RSA keys are too small, use at least %d bits", bits, weakMinRsaBits)
+		}
+	case *ecdsa.PublicKey:
+		if name := key.Curve.Params().Name; !slices.Contains(fipsCurves, name) {
+			return fmt.Sprintf("the %s curve is deprecated, use P-256, P-384 or P-521", name)
+		}
+	case []byte:
+		minBits, ok := hmacMinBits[alg]
+		if !ok {
+			minBits = hmacMinBits[string(jose.HS256)]
+		}
+		if bits := len(key) * 8; bits < minBits {
+			return fmt.Sprintf("%d bits symmetric keys are too small, use at least %d bits", bits, minBits)
+		}
+	}
+	return ""
+}
+
+// checkWeakKey adds a diagnostic at attrPath when using jwk with alg is weak,
+// or when alg can't be used with its key.
+func (p *JwkProviderData) checkWeakKey(diags *diag.Diagnostics, attrPath path.Path, jwk jose.JSONWebKey, alg string) {
+	reason := weakKeyReason(jwk, alg)
+	if reason == "" {
+		if alg == "" {
+			alg = jwk.Algorithm
+		}
+		if err := checkAlgorithmKey(jwk, alg); err != nil {
+			reason = err.Error()
+		}
+	}
+	if reason == "" {
+		return
+	}
+
+	if jwk.KeyID != "" {
+		p.addWeakDiagnostic(diags, attrPath, fmt.Sprintf("Key %q : %s", jwk.KeyID, reason))
+	} else {
+		p.addWeakDiagnostic(diags, attrPath, fmt.Sprintf("Key : %s", reason))
+	}
+}
+
+// addWeakDiagnostic adds detail at attrPath as a warning, or as configured by
+// the provider weak_algorithms.
+func (p *JwkProviderData) addWeakDiagnostic(diags *diag.Diagnostics, attrPath path.Path, detail string) {
+	severity := weakAlgorithmsWarning
+	if p != nil && p.weakAlgorithms != "" {
+		severity = p.weakAlgorithms
+	}
+
+	switch severity {
+	case weakAlgorithmsError:
+		diags.AddAttributeError(attrPath, "Weak key or algorithm", detail)
+	case weakAlgorithmsWarning:
+		diags.AddAttributeWarning(attrPath, "Weak key or algorithm", detail)
+	}
+}
+
+// checkWeakSigningKey calls checkWeakKey for the key of a signing key built
+// by signingKey.
+func (p *JwkProviderData) checkWeakSigningKey(diags *diag.Diagnostics, attrPath path.Path, key jose.SigningKey) {
+	jwk, ok := key.Key.(jose.JSONWebKey)
+	if !ok {
+		jwk = jose.JSONWebKey{Key: key.Key}
+	}
+	p.checkWeakKey(diags, attrPath, jwk, string(key.Algorithm))
+}
+
+// checkWeakKeys calls checkWeakKey for every key read by keySet, jwk being
+// null when they come from the jwks attribute, skipping the keys that can't
+// be parsed.
+func (p *JwkProviderData) checkWeakKeys(diags *diag.Diagnostics, jwk types.String, keys []json.RawMessage) {
+	for i, key := range keys {
+		parsed, err := jwkutil.ParseJwk(string(key))
+		if err != nil {
+			continue
+		}
+		p.checkWeakKey(diags, keySetIndexPath(jwk, i), parsed, "")
+	}
+}